@@ -0,0 +1,46 @@
+package main
+
+import (
+	"log"
+	"net/http"
+
+	echo "github.com/appnet-org/arpc/examples/echo_capnp/capnp"
+	"github.com/appnet-org/arpc/internal/serializer"
+	"github.com/appnet-org/arpc/pkg/gateway"
+	"github.com/appnet-org/arpc/pkg/rpc"
+)
+
+func main() {
+	client, err := rpc.NewClient(&serializer.CapnpSerializer{}, "127.0.0.1:9000")
+	if err != nil {
+		log.Fatal("Failed to create RPC client:", err)
+	}
+
+	router := gateway.NewRouter()
+	err = router.RegisterService(&gateway.ServiceDesc{
+		ServiceName: "echo.EchoService",
+		Methods: []*gateway.MethodDesc{
+			{
+				// No Path/HTTPMethod: falls back to the default
+				// "POST /v1/echo.EchoService/Echo" binding, decoding the
+				// whole request from the body via the capnp serializer
+				// below. echo.EchoRequest exposes its fields through
+				// accessor methods (GetContent/CreateEchoRequest), not
+				// exported struct fields, so it can't be populated from
+				// path/query params by populateFromParams's reflection.
+				Method:      "Echo",
+				NewRequest:  func() any { return &echo.EchoRequest{} },
+				NewResponse: func() any { return &echo.EchoRequest{} },
+			},
+		},
+	})
+	if err != nil {
+		log.Fatal("Failed to register gateway routes:", err)
+	}
+
+	handler := gateway.NewHandler(client, router, gateway.WithSerializer("application/capnp", &serializer.CapnpSerializer{}))
+	log.Println("HTTP gateway listening on :8080")
+	if err := http.ListenAndServe(":8080", handler); err != nil {
+		log.Fatal("HTTP gateway failed:", err)
+	}
+}
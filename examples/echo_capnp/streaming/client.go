@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"io"
+	"log"
+
+	echo "github.com/appnet-org/arpc/examples/echo_capnp/capnp"
+	"github.com/appnet-org/arpc/internal/serializer"
+	"github.com/appnet-org/arpc/pkg/rpc"
+)
+
+func main() {
+	client, err := rpc.NewClient(&serializer.CapnpSerializer{}, "127.0.0.1:9001")
+	if err != nil {
+		log.Fatal("Failed to create RPC client:", err)
+	}
+	ctx := context.Background()
+
+	// Server-streaming: send one request, read responses until the server closes.
+	stream, err := client.NewStream(ctx, "echo.EchoService", "EchoServerStream")
+	if err != nil {
+		log.Fatal("Failed to open server stream:", err)
+	}
+	req, err := echo.CreateEchoRequest("hello")
+	if err != nil {
+		log.Fatal("Failed to build request:", err)
+	}
+	if err := stream.Send(req); err != nil {
+		log.Fatal("Failed to send request:", err)
+	}
+	stream.CloseSend()
+	for {
+		var resp echo.EchoRequest
+		if err := stream.Recv(&resp); err != nil {
+			if err == io.EOF {
+				break
+			}
+			log.Println("server stream ended:", err)
+			break
+		}
+		content, _ := resp.GetContent()
+		log.Printf("server-stream chunk: %s\n", content)
+	}
+
+	// Client-streaming: send several requests, then read the single aggregate response.
+	cstream, err := client.NewStream(ctx, "echo.EchoService", "EchoClientStream")
+	if err != nil {
+		log.Fatal("Failed to open client stream:", err)
+	}
+	for _, word := range []string{"foo", "bar", "baz"} {
+		part, err := echo.CreateEchoRequest(word)
+		if err != nil {
+			log.Fatal("Failed to build request:", err)
+		}
+		if err := cstream.Send(part); err != nil {
+			log.Fatal("Failed to send request:", err)
+		}
+	}
+	cstream.CloseSend()
+
+	var final echo.EchoRequest
+	if err := cstream.Recv(&final); err != nil {
+		log.Fatal("Failed to receive aggregate response:", err)
+	}
+	content, _ := final.GetContent()
+	log.Printf("client-stream result: %s\n", content)
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"io"
+	"log"
+
+	echo "github.com/appnet-org/arpc/examples/echo_capnp/capnp"
+	"github.com/appnet-org/arpc/internal/serializer"
+	"github.com/appnet-org/arpc/pkg/rpc"
+)
+
+// echoStreamServer implements the server-streaming and client-streaming methods
+// of EchoService: EchoServerStream sends the request content back one char at a
+// time, and EchoClientStream accumulates every request it receives before
+// replying once with the concatenated result.
+type echoStreamServer struct{}
+
+// EchoServerStream is a server-streaming method: one request, many responses.
+func (s *echoStreamServer) EchoServerStream(srv any, stream rpc.ServerStream) error {
+	var req echo.EchoRequest
+	if err := stream.Recv(&req); err != nil {
+		return err
+	}
+
+	content, err := req.GetContent()
+	if err != nil {
+		return err
+	}
+
+	for _, c := range content {
+		resp, err := echo.CreateEchoRequest(string(c))
+		if err != nil {
+			return err
+		}
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+	stream.CloseSend()
+	return nil
+}
+
+// EchoClientStream is a client-streaming method: many requests, one response.
+func (s *echoStreamServer) EchoClientStream(srv any, stream rpc.ServerStream) error {
+	var combined string
+	for {
+		var req echo.EchoRequest
+		err := stream.Recv(&req)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			break // undecodable message: stop reading and reply with what was gathered
+		}
+		content, err := req.GetContent()
+		if err != nil {
+			return err
+		}
+		combined += content
+	}
+
+	resp, err := echo.CreateEchoRequest(combined)
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(resp); err != nil {
+		return err
+	}
+	stream.CloseSend()
+	return nil
+}
+
+func main() {
+	srv := &echoStreamServer{}
+
+	server, err := rpc.NewServer("127.0.0.1:9001", &serializer.CapnpSerializer{}, nil)
+	if err != nil {
+		log.Fatal("Failed to create RPC server:", err)
+	}
+
+	server.RegisterService(&rpc.ServiceDesc{
+		ServiceImpl: srv,
+		ServiceName: "echo.EchoService",
+		Methods: map[string]*rpc.MethodDesc{
+			"EchoServerStream": {
+				MethodName:    "EchoServerStream",
+				ServerStreams: true,
+				StreamHandler: func(srv any, stream rpc.ServerStream) error {
+					return srv.(*echoStreamServer).EchoServerStream(srv, stream)
+				},
+			},
+			"EchoClientStream": {
+				MethodName:    "EchoClientStream",
+				ClientStreams: true,
+				StreamHandler: func(srv any, stream rpc.ServerStream) error {
+					return srv.(*echoStreamServer).EchoClientStream(srv, stream)
+				},
+			},
+		},
+	}, srv)
+
+	server.Start()
+}
@@ -6,26 +6,44 @@ import (
 	"net"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/appnet-org/arpc/internal/transport/balancer/random"
 	"github.com/appnet-org/arpc/internal/transport/balancer/types"
+	"github.com/appnet-org/arpc/pkg/health"
+	"github.com/appnet-org/arpc/pkg/registry"
 )
 
-// Resolver handles DNS resolution and load balancing
+// Resolver turns a service name or literal address into a concrete UDP target,
+// using a Registry to discover nodes and a Balancer to pick among them.
 type Resolver struct {
+	registry registry.Registry
 	balancer types.Balancer
+	prober   *health.Prober // optional; filters unhealthy nodes before Pick
+
+	mu    sync.RWMutex
+	cache map[string][]*registry.Node // service name -> last known nodes
 }
 
-// NewResolver creates a new resolver with the specified balancer
-func NewResolver(balancer types.Balancer) *Resolver {
+// NewResolver creates a new resolver backed by reg, selecting nodes with balancer.
+func NewResolver(reg registry.Registry, balancer types.Balancer) *Resolver {
 	return &Resolver{
+		registry: reg,
 		balancer: balancer,
+		cache:    make(map[string][]*registry.Node),
 	}
 }
 
-// ResolveUDPTarget resolves a UDP address string that may be an IP, FQDN, or empty.
-// If it's empty or ":port", it binds to 0.0.0.0:<port>. For FQDNs, it uses the configured balancer
-// to select an IP from the resolved addresses.
+// WithHealthProber attaches a health.Prober whose Filter is applied to the
+// candidate node set before every balancer.Pick call.
+func (r *Resolver) WithHealthProber(p *health.Prober) *Resolver {
+	r.prober = p
+	return r
+}
+
+// ResolveUDPTarget resolves a UDP address string that may be an IP, ":port",
+// or a service name known to the Resolver's Registry. For service names, it
+// uses the configured balancer over the Registry's live node set.
 func (r *Resolver) ResolveUDPTarget(addr string) (*net.UDPAddr, error) {
 	if addr == "" {
 		return &net.UDPAddr{IP: net.IPv4zero, Port: 0}, nil
@@ -56,29 +74,92 @@ func (r *Resolver) ResolveUDPTarget(addr string) (*net.UDPAddr, error) {
 		return &net.UDPAddr{IP: ip, Port: port}, nil
 	}
 
-	// FQDN case: resolve all IPs and use balancer
-	ips, err := net.LookupIP(host)
-	if err != nil || len(ips) == 0 {
-		return nil, fmt.Errorf("DNS lookup failed for %q: %w", host, err)
+	// Service/FQDN case: resolve nodes through the registry and let the
+	// balancer pick one.
+	nodes, err := r.nodesFor(host)
+	if err != nil {
+		return nil, err
 	}
 
-	// Log all resolved IPs
-	log.Printf("DNS lookup for %s returned IPs:", host)
-	for i, resolvedIP := range ips {
-		log.Printf("  [%d] %s", i, resolvedIP.String())
+	if r.prober != nil {
+		if healthy := r.prober.Filter(nodes); len(healthy) > 0 {
+			nodes = healthy
+		}
 	}
 
-	// Use the balancer to pick an IP
-	chosen := r.balancer.Pick(host, ips)
+	chosen := r.balancer.Pick(host, nodes)
 	if chosen == nil {
-		return nil, fmt.Errorf("balancer failed to select an IP for %q", host)
+		return nil, fmt.Errorf("balancer failed to select a node for %q", host)
+	}
+
+	nodePort := port
+	if chosen.Port != 0 {
+		nodePort = chosen.Port
 	}
 
-	log.Printf("Balancer '%s' selected %s → %s:%d", r.balancer.Name(), addr, chosen, port)
-	return &net.UDPAddr{IP: chosen, Port: port}, nil
+	chosenIP := net.ParseIP(chosen.Address)
+	if chosenIP == nil {
+		return nil, fmt.Errorf("node %q for %q has invalid address %q", chosen.ID, host, chosen.Address)
+	}
+
+	log.Printf("Balancer '%s' selected %s → %s:%d", r.balancer.Name(), addr, chosenIP, nodePort)
+	return &net.UDPAddr{IP: chosenIP, Port: nodePort}, nil
+}
+
+// nodesFor returns the current node set for service, seeding it with a
+// synchronous GetService call the first time it's requested and keeping it
+// fresh afterward via a background Watch loop.
+func (r *Resolver) nodesFor(service string) ([]*registry.Node, error) {
+	r.mu.RLock()
+	nodes, ok := r.cache[service]
+	r.mu.RUnlock()
+	if ok {
+		return nodes, nil
+	}
+
+	svc, err := r.registry.GetService(service)
+	if err != nil || len(svc.Nodes) == 0 {
+		return nil, fmt.Errorf("failed to resolve service %q: %w", service, err)
+	}
+
+	r.mu.Lock()
+	r.cache[service] = svc.Nodes
+	r.mu.Unlock()
+
+	go r.watch(service)
+
+	return svc.Nodes, nil
+}
+
+// watch consumes the Registry's Watcher for service and keeps the cache
+// up to date as nodes come and go. It runs for the lifetime of the process
+// once a service has been resolved at least once.
+func (r *Resolver) watch(service string) {
+	watcher, err := r.registry.Watch(service)
+	if err != nil {
+		log.Printf("Failed to start watcher for %q: %v", service, err)
+		return
+	}
+
+	for {
+		result, err := watcher.Next()
+		if err != nil {
+			log.Printf("Watcher for %q stopped: %v", service, err)
+			return
+		}
+
+		r.mu.Lock()
+		if result.Action == registry.Delete {
+			delete(r.cache, service)
+		} else {
+			r.cache[service] = result.Service.Nodes
+		}
+		r.mu.Unlock()
+	}
 }
 
-// DefaultResolver creates a resolver with a random balancer (for backward compatibility)
+// DefaultResolver creates a resolver with a DNS-backed registry and a random
+// balancer (for backward compatibility with plain FQDN targets).
 func DefaultResolver() *Resolver {
-	return NewResolver(random.NewRandomBalancer())
+	return NewResolver(registry.NewDNSRegistry(), random.NewRandomBalancer())
 }
@@ -0,0 +1,188 @@
+package balancer
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/appnet-org/arpc/pkg/registry"
+)
+
+// fakeBalancer always picks the first node, so resolver tests can assert on
+// cache contents without depending on a real balancing strategy.
+type fakeBalancer struct{}
+
+func (fakeBalancer) Name() string { return "fake" }
+
+func (fakeBalancer) Pick(service string, nodes []*registry.Node) *registry.Node {
+	if len(nodes) == 0 {
+		return nil
+	}
+	return nodes[0]
+}
+
+// fakeWatcher replays a fixed sequence of Results, then blocks forever on a
+// channel that Stop closes.
+type fakeWatcher struct {
+	results chan *registry.Result
+	stopped chan struct{}
+}
+
+func newFakeWatcher(results ...*registry.Result) *fakeWatcher {
+	ch := make(chan *registry.Result, len(results))
+	for _, r := range results {
+		ch <- r
+	}
+	return &fakeWatcher{results: ch, stopped: make(chan struct{})}
+}
+
+func (w *fakeWatcher) Next() (*registry.Result, error) {
+	select {
+	case r, ok := <-w.results:
+		if !ok {
+			<-w.stopped
+			return nil, fmt.Errorf("fakeWatcher: closed")
+		}
+		return r, nil
+	case <-w.stopped:
+		return nil, fmt.Errorf("fakeWatcher: stopped")
+	}
+}
+
+func (w *fakeWatcher) Stop() { close(w.stopped) }
+
+// fakeRegistry serves a fixed initial Service per name and hands out a
+// pre-seeded fakeWatcher for it.
+type fakeRegistry struct {
+	services map[string]*registry.Service
+	watchers map[string]*fakeWatcher
+}
+
+func (r *fakeRegistry) Register(s *registry.Service) error   { return nil }
+func (r *fakeRegistry) Deregister(s *registry.Service) error { return nil }
+
+func (r *fakeRegistry) GetService(name string) (*registry.Service, error) {
+	svc, ok := r.services[name]
+	if !ok {
+		return nil, fmt.Errorf("fakeRegistry: unknown service %q", name)
+	}
+	return svc, nil
+}
+
+func (r *fakeRegistry) ListServices() ([]*registry.Service, error) {
+	return nil, nil
+}
+
+func (r *fakeRegistry) Watch(service string) (registry.Watcher, error) {
+	w, ok := r.watchers[service]
+	if !ok {
+		return nil, fmt.Errorf("fakeRegistry: no watcher for %q", service)
+	}
+	return w, nil
+}
+
+func node(id, addr string, port int) *registry.Node {
+	return &registry.Node{ID: id, Address: addr, Port: port}
+}
+
+// TestNodesForSeedsCacheFromGetService checks that the first resolution for
+// a service populates the cache via a synchronous GetService call, without
+// requiring a watch event.
+func TestNodesForSeedsCacheFromGetService(t *testing.T) {
+	reg := &fakeRegistry{
+		services: map[string]*registry.Service{
+			"svc": {Name: "svc", Nodes: []*registry.Node{node("n1", "10.0.0.1", 9000)}},
+		},
+		watchers: map[string]*fakeWatcher{"svc": newFakeWatcher()},
+	}
+	r := NewResolver(reg, fakeBalancer{})
+
+	nodes, err := r.nodesFor("svc")
+	if err != nil {
+		t.Fatalf("nodesFor: %v", err)
+	}
+	if len(nodes) != 1 || nodes[0].ID != "n1" {
+		t.Fatalf("nodesFor = %+v, want [n1]", nodes)
+	}
+}
+
+// TestWatchReplacesCacheWithFullSnapshot checks that an Update event's
+// Service.Nodes wholesale replaces the cached node list, rather than being
+// merged with (or appended to) whatever was cached before. This is what lets
+// a watcher backend report a full snapshot per event, as etcd's does, and
+// have it take effect here without additional merge logic.
+func TestWatchReplacesCacheWithFullSnapshot(t *testing.T) {
+	initial := []*registry.Node{node("n1", "10.0.0.1", 9000)}
+	updated := []*registry.Node{node("n2", "10.0.0.2", 9000), node("n3", "10.0.0.3", 9000)}
+
+	watcher := newFakeWatcher(&registry.Result{
+		Action:  registry.Update,
+		Service: &registry.Service{Name: "svc", Nodes: updated},
+	})
+	reg := &fakeRegistry{
+		services: map[string]*registry.Service{"svc": {Name: "svc", Nodes: initial}},
+		watchers: map[string]*fakeWatcher{"svc": watcher},
+	}
+	r := NewResolver(reg, fakeBalancer{})
+
+	if _, err := r.nodesFor("svc"); err != nil {
+		t.Fatalf("nodesFor: %v", err)
+	}
+
+	waitForCache(t, r, "svc", func(nodes []*registry.Node) bool {
+		return len(nodes) == 2
+	})
+
+	r.mu.RLock()
+	nodes := r.cache["svc"]
+	r.mu.RUnlock()
+	if len(nodes) != 2 || nodes[0].ID != "n2" || nodes[1].ID != "n3" {
+		t.Fatalf("cache[svc] = %+v, want [n2 n3]", nodes)
+	}
+}
+
+// TestWatchDeleteEvictsCache checks that a Delete event drops the service
+// from the cache entirely, so the next ResolveUDPTarget call re-seeds it via
+// GetService instead of serving stale nodes.
+func TestWatchDeleteEvictsCache(t *testing.T) {
+	watcher := newFakeWatcher(&registry.Result{Action: registry.Delete})
+	reg := &fakeRegistry{
+		services: map[string]*registry.Service{
+			"svc": {Name: "svc", Nodes: []*registry.Node{node("n1", "10.0.0.1", 9000)}},
+		},
+		watchers: map[string]*fakeWatcher{"svc": watcher},
+	}
+	r := NewResolver(reg, fakeBalancer{})
+
+	if _, err := r.nodesFor("svc"); err != nil {
+		t.Fatalf("nodesFor: %v", err)
+	}
+
+	waitForCache(t, r, "svc", func(nodes []*registry.Node) bool {
+		return nodes == nil
+	})
+}
+
+// waitForCache polls r's cache for service until cond is satisfied, since
+// watch() applies events on its own goroutine.
+func waitForCache(t *testing.T, r *Resolver, service string, cond func([]*registry.Node) bool) {
+	t.Helper()
+	deadline := make(chan struct{})
+	go func() {
+		for {
+			r.mu.RLock()
+			nodes, ok := r.cache[service]
+			r.mu.RUnlock()
+			if cond(nodes) && (ok || nodes == nil) {
+				close(deadline)
+				return
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}()
+	select {
+	case <-deadline:
+	case <-time.After(time.Second):
+		t.Fatalf("cache for %q never reached expected state", service)
+	}
+}
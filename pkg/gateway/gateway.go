@@ -0,0 +1,157 @@
+// Package gateway translates HTTP/REST requests into arpc UDP RPCs, so
+// existing services can be reached from plain HTTP clients without a
+// hand-rolled bridge like examples/echo_capnp/frontend.go.
+package gateway
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/appnet-org/arpc/pkg/metadata"
+	"github.com/appnet-org/arpc/pkg/rpc"
+	"github.com/appnet-org/arpc/pkg/serializer"
+)
+
+// MethodDesc describes one HTTP binding for an RPC method.
+type MethodDesc struct {
+	Service     string
+	Method      string
+	HTTPMethod  string // "GET", "POST", ...
+	Path        string // may contain "{name}" placeholders, e.g. "/echo/{key}"
+	NewRequest  func() any
+	NewResponse func() any
+}
+
+// ServiceDesc groups the HTTP bindings for one RPC service, parallel to
+// rpc.ServiceDesc on the server side. Code generators that already emit an
+// rpc.ServiceDesc can emit one of these alongside it.
+type ServiceDesc struct {
+	ServiceName string
+	Methods     []*MethodDesc
+}
+
+// Option customizes a Handler built by NewHandler.
+type Option func(*Handler)
+
+// WithSerializer registers ser as the (de)serializer used for bodies whose
+// Content-Type/Accept is mime. The default registry already covers
+// "application/json"; call this to add "application/protobuf",
+// "application/capnp", or override the default.
+func WithSerializer(mime string, ser serializer.Serializer) Option {
+	return func(h *Handler) {
+		h.serializers.Register(mime, ser)
+	}
+}
+
+// Handler is an http.Handler that dispatches matched requests to an
+// rpc.Client. net/http serves each inbound request on its own goroutine, so
+// ServeHTTP calls client.Call concurrently by construction; this relies on
+// rpc.Client correlating responses by rpcID rather than assuming whatever
+// frame arrives next on the socket belongs to the caller that's waiting.
+type Handler struct {
+	client      *rpc.Client
+	router      Router
+	serializers *SerializerRegistry
+}
+
+// NewHandler builds an http.Handler that resolves inbound requests via router
+// and invokes them against client.
+func NewHandler(client *rpc.Client, router Router, opts ...Option) http.Handler {
+	h := &Handler{
+		client:      client,
+		router:      router,
+		serializers: defaultSerializerRegistry(),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	desc, params, ok := h.router.Match(r)
+	if !ok {
+		http.Error(w, fmt.Sprintf("no route for %s %s", r.Method, r.URL.Path), http.StatusNotFound)
+		return
+	}
+
+	mime := negotiateMIME(r)
+	ser, ok := h.serializers.Lookup(mime)
+	if !ok {
+		http.Error(w, fmt.Sprintf("unsupported content type %q", mime), http.StatusUnsupportedMediaType)
+		return
+	}
+
+	req := desc.NewRequest()
+	if err := decodeRequest(r, ser, params, req); err != nil {
+		http.Error(w, fmt.Sprintf("failed to decode request: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	ctx := metadata.NewOutgoingContext(r.Context(), headerToMetadata(r.Header))
+
+	resp := desc.NewResponse()
+	if err := h.client.Call(ctx, desc.Service, desc.Method, req, resp); err != nil {
+		http.Error(w, fmt.Sprintf("RPC call failed: %v", err), http.StatusBadGateway)
+		return
+	}
+
+	respBody, err := ser.Marshal(resp)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to marshal response: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	respMD := metadata.FromOutgoingContext(ctx)
+	metadataToHeader(respMD, w.Header())
+	w.Header().Set("Content-Type", mime)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(respBody)
+}
+
+// decodeRequest populates req from the HTTP request body (for methods that
+// carry one) or from path/query parameters (for GETs and templated routes).
+func decodeRequest(r *http.Request, ser serializer.Serializer, params map[string]string, req any) error {
+	if r.Method == http.MethodGet || r.Method == http.MethodDelete {
+		values := map[string]string{}
+		for k, v := range r.URL.Query() {
+			if len(v) > 0 {
+				values[k] = v[0]
+			}
+		}
+		for k, v := range params {
+			values[k] = v
+		}
+		return populateFromParams(req, values)
+	}
+
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read body: %w", err)
+	}
+	defer r.Body.Close()
+
+	if err := ser.Unmarshal(body, req); err != nil {
+		return err
+	}
+	return populateFromParams(req, params)
+}
+
+// headerToMetadata copies HTTP request headers into an arpc metadata.MD.
+func headerToMetadata(h http.Header) metadata.MD {
+	md := metadata.MD{}
+	for k, v := range h {
+		if len(v) > 0 {
+			md[k] = v[0]
+		}
+	}
+	return md
+}
+
+// metadataToHeader copies an arpc metadata.MD into HTTP response headers.
+func metadataToHeader(md metadata.MD, h http.Header) {
+	for k, v := range md {
+		h.Set(k, v)
+	}
+}
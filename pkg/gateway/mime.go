@@ -0,0 +1,26 @@
+package gateway
+
+import (
+	"mime"
+	"net/http"
+)
+
+// defaultMIME is used when a request carries no Content-Type/Accept header.
+const defaultMIME = "application/json"
+
+// negotiateMIME picks the MIME type to (de)serialize with: the request's
+// Content-Type if present (bodied requests), falling back to Accept (GETs),
+// falling back to defaultMIME.
+func negotiateMIME(r *http.Request) string {
+	if ct := r.Header.Get("Content-Type"); ct != "" {
+		if parsed, _, err := mime.ParseMediaType(ct); err == nil {
+			return parsed
+		}
+	}
+	if accept := r.Header.Get("Accept"); accept != "" && accept != "*/*" {
+		if parsed, _, err := mime.ParseMediaType(accept); err == nil {
+			return parsed
+		}
+	}
+	return defaultMIME
+}
@@ -0,0 +1,84 @@
+package gateway
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ParamSetter lets a request type take over param binding itself.
+// populateFromParams's reflection only sees plain exported struct fields, so
+// generated message types that expose their contents via accessor methods
+// instead (e.g. capnp's GetContent/SetContent style) must implement this to
+// participate in path/query parameter binding.
+type ParamSetter interface {
+	SetParam(name, value string) error
+}
+
+// populateFromParams assigns string-valued params (path parameters and,
+// for GET/DELETE, query parameters) onto req. If req implements ParamSetter,
+// every param is routed through SetParam; otherwise params are matched
+// case-insensitively onto req's exported fields, a qson-like best-effort
+// mapping rather than a full decoder.
+func populateFromParams(req any, params map[string]string) error {
+	if len(params) == 0 {
+		return nil
+	}
+
+	if ps, ok := req.(ParamSetter); ok {
+		for name, value := range params {
+			if err := ps.SetParam(name, value); err != nil {
+				return fmt.Errorf("gateway: failed to set %s: %w", name, err)
+			}
+		}
+		return nil
+	}
+
+	v := reflect.ValueOf(req)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return nil // req isn't a plain struct pointer; leave body decoding to do the work
+	}
+	v = v.Elem()
+	t := v.Type()
+
+	for name, value := range params {
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !strings.EqualFold(field.Name, name) {
+				continue
+			}
+			if err := setField(v.Field(i), value); err != nil {
+				return fmt.Errorf("gateway: failed to set %s: %w", field.Name, err)
+			}
+			break
+		}
+	}
+	return nil
+}
+
+func setField(field reflect.Value, value string) error {
+	if !field.CanSet() {
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported field kind %s", field.Kind())
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package gateway
+
+import "testing"
+
+// echoParams is a plain JSON-friendly struct, the shape populateFromParams's
+// reflection path is actually designed for (as opposed to the capnp example's
+// accessor-method types, which must implement ParamSetter instead).
+type echoParams struct {
+	Key   string
+	Count int
+	Loud  bool
+}
+
+func TestPopulateFromParamsPlainStruct(t *testing.T) {
+	req := &echoParams{}
+	err := populateFromParams(req, map[string]string{
+		"key":   "hello",
+		"count": "3",
+		"loud":  "true",
+	})
+	if err != nil {
+		t.Fatalf("populateFromParams: %v", err)
+	}
+	if req.Key != "hello" || req.Count != 3 || !req.Loud {
+		t.Errorf("req = %+v, want {hello 3 true}", req)
+	}
+}
+
+func TestPopulateFromParamsIsCaseInsensitive(t *testing.T) {
+	req := &echoParams{}
+	if err := populateFromParams(req, map[string]string{"KEY": "hello"}); err != nil {
+		t.Fatalf("populateFromParams: %v", err)
+	}
+	if req.Key != "hello" {
+		t.Errorf("req.Key = %q, want %q", req.Key, "hello")
+	}
+}
+
+func TestPopulateFromParamsRejectsBadInt(t *testing.T) {
+	req := &echoParams{}
+	if err := populateFromParams(req, map[string]string{"count": "not-a-number"}); err == nil {
+		t.Fatal("populateFromParams: expected error for non-numeric count, got nil")
+	}
+}
+
+// accessorOnlyRequest stands in for a capnp-generated type: its content is
+// only reachable through a method, not an exported field, so it must
+// implement ParamSetter to support param binding at all.
+type accessorOnlyRequest struct {
+	content string
+}
+
+func (r *accessorOnlyRequest) SetParam(name, value string) error {
+	if name != "key" {
+		return nil
+	}
+	r.content = value
+	return nil
+}
+
+func TestPopulateFromParamsUsesParamSetter(t *testing.T) {
+	req := &accessorOnlyRequest{}
+	if err := populateFromParams(req, map[string]string{"key": "hello"}); err != nil {
+		t.Fatalf("populateFromParams: %v", err)
+	}
+	if req.content != "hello" {
+		t.Errorf("req.content = %q, want %q", req.content, "hello")
+	}
+}
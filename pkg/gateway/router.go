@@ -0,0 +1,103 @@
+package gateway
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Router resolves an inbound HTTP request to the RPC method it maps to,
+// extracting any path parameters its route template declares.
+type Router interface {
+	// Match returns the MethodDesc bound to r along with its path
+	// parameters (e.g. {"key": "hello"} for a "/echo/{key}" route), or
+	// ok=false if no route matches.
+	Match(r *http.Request) (desc *MethodDesc, params map[string]string, ok bool)
+}
+
+// route is one compiled entry in a DefaultRouter.
+type route struct {
+	httpMethod string
+	segments   []segment
+	desc       *MethodDesc
+}
+
+type segment struct {
+	literal string
+	param   string // non-empty if this segment is a "{name}" placeholder
+}
+
+// DefaultRouter matches both gRPC-style paths ("POST /v1/{service}/{method}")
+// and user-defined templated routes ("GET /echo/{key}") registered via
+// RegisterService.
+type DefaultRouter struct {
+	routes []*route
+}
+
+// NewRouter creates an empty DefaultRouter.
+func NewRouter() *DefaultRouter {
+	return &DefaultRouter{}
+}
+
+// RegisterService compiles every MethodDesc in desc into a route. A
+// MethodDesc with no explicit Path/HTTPMethod falls back to the gRPC-style
+// "POST /v1/{ServiceName}/{MethodName}" convention.
+func (rt *DefaultRouter) RegisterService(desc *ServiceDesc) error {
+	for _, m := range desc.Methods {
+		m.Service = desc.ServiceName
+		httpMethod := m.HTTPMethod
+		path := m.Path
+		if path == "" {
+			httpMethod = http.MethodPost
+			path = fmt.Sprintf("/v1/%s/%s", desc.ServiceName, m.Method)
+		}
+
+		segs, err := compilePath(path)
+		if err != nil {
+			return fmt.Errorf("gateway: bad path %q for %s.%s: %w", path, desc.ServiceName, m.Method, err)
+		}
+		rt.routes = append(rt.routes, &route{httpMethod: httpMethod, segments: segs, desc: m})
+	}
+	return nil
+}
+
+func compilePath(path string) ([]segment, error) {
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	segs := make([]segment, 0, len(parts))
+	for _, p := range parts {
+		if strings.HasPrefix(p, "{") && strings.HasSuffix(p, "}") {
+			segs = append(segs, segment{param: strings.TrimSuffix(strings.TrimPrefix(p, "{"), "}")})
+		} else {
+			segs = append(segs, segment{literal: p})
+		}
+	}
+	return segs, nil
+}
+
+// Match implements Router.
+func (rt *DefaultRouter) Match(r *http.Request) (*MethodDesc, map[string]string, bool) {
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	for _, rte := range rt.routes {
+		if rte.httpMethod != r.Method || len(rte.segments) != len(parts) {
+			continue
+		}
+
+		params := map[string]string{}
+		matched := true
+		for i, seg := range rte.segments {
+			if seg.param != "" {
+				params[seg.param] = parts[i]
+				continue
+			}
+			if seg.literal != parts[i] {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return rte.desc, params, true
+		}
+	}
+	return nil, nil, false
+}
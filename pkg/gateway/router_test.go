@@ -0,0 +1,98 @@
+package gateway
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func desc(service, method, httpMethod, path string) *MethodDesc {
+	return &MethodDesc{Service: service, Method: method, HTTPMethod: httpMethod, Path: path}
+}
+
+func TestRouterMatchesTemplatedRoute(t *testing.T) {
+	rt := NewRouter()
+	if err := rt.RegisterService(&ServiceDesc{
+		ServiceName: "Echo",
+		Methods:     []*MethodDesc{desc("Echo", "Get", http.MethodGet, "/echo/{key}")},
+	}); err != nil {
+		t.Fatalf("RegisterService: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/echo/hello", nil)
+	got, params, ok := rt.Match(req)
+	if !ok {
+		t.Fatal("Match: expected a route, got none")
+	}
+	if got.Method != "Get" {
+		t.Errorf("Method = %q, want %q", got.Method, "Get")
+	}
+	if params["key"] != "hello" {
+		t.Errorf("params[%q] = %q, want %q", "key", params["key"], "hello")
+	}
+}
+
+func TestRouterFallsBackToGRPCStylePath(t *testing.T) {
+	rt := NewRouter()
+	if err := rt.RegisterService(&ServiceDesc{
+		ServiceName: "Echo",
+		Methods:     []*MethodDesc{desc("Echo", "Say", "", "")},
+	}); err != nil {
+		t.Fatalf("RegisterService: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/Echo/Say", nil)
+	got, _, ok := rt.Match(req)
+	if !ok {
+		t.Fatal("Match: expected the default gRPC-style route, got none")
+	}
+	if got.Method != "Say" {
+		t.Errorf("Method = %q, want %q", got.Method, "Say")
+	}
+}
+
+func TestRouterRejectsWrongMethodOrSegmentCount(t *testing.T) {
+	rt := NewRouter()
+	if err := rt.RegisterService(&ServiceDesc{
+		ServiceName: "Echo",
+		Methods:     []*MethodDesc{desc("Echo", "Get", http.MethodGet, "/echo/{key}")},
+	}); err != nil {
+		t.Fatalf("RegisterService: %v", err)
+	}
+
+	cases := []*http.Request{
+		httptest.NewRequest(http.MethodPost, "/echo/hello", nil),      // wrong HTTP method
+		httptest.NewRequest(http.MethodGet, "/echo/hello/extra", nil), // wrong segment count
+		httptest.NewRequest(http.MethodGet, "/other/hello", nil),      // literal mismatch
+	}
+	for _, req := range cases {
+		if _, _, ok := rt.Match(req); ok {
+			t.Errorf("Match(%s %s): expected no match", req.Method, req.URL.Path)
+		}
+	}
+}
+
+func TestRouterMatchesFirstRegisteredRouteOnAmbiguity(t *testing.T) {
+	rt := NewRouter()
+	if err := rt.RegisterService(&ServiceDesc{
+		ServiceName: "Svc",
+		Methods: []*MethodDesc{
+			desc("Svc", "First", http.MethodGet, "/thing/{id}"),
+			desc("Svc", "Second", http.MethodGet, "/thing/{id}"),
+		},
+	}); err != nil {
+		t.Fatalf("RegisterService: %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/thing/42", nil)
+	got, params, ok := rt.Match(req)
+	if !ok {
+		t.Fatal("Match: expected a route, got none")
+	}
+	if got.Method != "First" {
+		t.Errorf("Method = %q, want %q (first registered wins)", got.Method, "First")
+	}
+	if params["id"] != "42" {
+		t.Errorf("params[%q] = %q, want %q", "id", params["id"], "42")
+	}
+}
@@ -0,0 +1,49 @@
+package gateway
+
+import (
+	"encoding/json"
+
+	"github.com/appnet-org/arpc/pkg/serializer"
+)
+
+// SerializerRegistry resolves the serializer.Serializer to use for a given
+// HTTP Content-Type/Accept MIME type.
+type SerializerRegistry struct {
+	byMIME map[string]serializer.Serializer
+}
+
+// NewSerializerRegistry creates an empty registry.
+func NewSerializerRegistry() *SerializerRegistry {
+	return &SerializerRegistry{byMIME: make(map[string]serializer.Serializer)}
+}
+
+// Register associates ser with mime, overwriting any existing entry.
+func (s *SerializerRegistry) Register(mime string, ser serializer.Serializer) {
+	s.byMIME[mime] = ser
+}
+
+// Lookup returns the serializer registered for mime, if any.
+func (s *SerializerRegistry) Lookup(mime string) (serializer.Serializer, bool) {
+	ser, ok := s.byMIME[mime]
+	return ser, ok
+}
+
+// defaultSerializerRegistry seeds a registry with JSON support; callers add
+// "application/protobuf" and "application/capnp" via WithSerializer since
+// those depend on generated, per-service message types.
+func defaultSerializerRegistry() *SerializerRegistry {
+	reg := NewSerializerRegistry()
+	reg.Register("application/json", &jsonSerializer{})
+	return reg
+}
+
+// jsonSerializer adapts encoding/json to serializer.Serializer.
+type jsonSerializer struct{}
+
+func (*jsonSerializer) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (*jsonSerializer) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
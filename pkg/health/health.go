@@ -0,0 +1,144 @@
+// Package health implements a gRPC-style health-checking service that rides
+// on top of rpc.Server: a unary Check for point-in-time polling and a
+// streaming Watch for push notification of status changes.
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/appnet-org/arpc/pkg/rpc"
+)
+
+// ServiceName is the name the health service registers itself under.
+const ServiceName = "arpc.health.v1.Health"
+
+// HealthCheckRequest names the service whose status is being queried. An
+// empty Service asks about the server as a whole.
+type HealthCheckRequest struct {
+	Service string
+}
+
+// HealthCheckResponse carries the current status for the requested service.
+type HealthCheckResponse struct {
+	Status Status
+}
+
+// HealthServer tracks per-service serving status and answers Check/Watch
+// requests about it. The zero value is not usable; construct with NewServer.
+type HealthServer struct {
+	mu       sync.Mutex
+	statuses map[string]Status
+	watchers map[string][]chan Status
+}
+
+// NewServer creates an empty HealthServer. Until SetServingStatus is called
+// for a service, Watch reports it as Unknown and Check returns an error for it.
+func NewServer() *HealthServer {
+	return &HealthServer{
+		statuses: make(map[string]Status),
+		watchers: make(map[string][]chan Status),
+	}
+}
+
+// SetServingStatus updates the status for service and notifies any active
+// Watch streams for it.
+func (h *HealthServer) SetServingStatus(service string, status Status) {
+	h.mu.Lock()
+	h.statuses[service] = status
+	watchers := h.watchers[service]
+	h.mu.Unlock()
+
+	for _, ch := range watchers {
+		select {
+		case ch <- status:
+		default:
+			// Slow watcher; drop the update rather than block SetServingStatus.
+		}
+	}
+}
+
+// Check implements the unary Check RPC.
+func (h *HealthServer) Check(ctx context.Context, req *HealthCheckRequest) (*HealthCheckResponse, error) {
+	h.mu.Lock()
+	status, ok := h.statuses[req.Service]
+	h.mu.Unlock()
+	if !ok {
+		return nil, fmt.Errorf("health: unknown service %q", req.Service)
+	}
+	return &HealthCheckResponse{Status: status}, nil
+}
+
+// Watch implements the streaming Watch RPC: it reads one HealthCheckRequest
+// naming the service to watch, then pushes a HealthCheckResponse every time
+// that service's status changes. It returns only when a Send fails; the
+// client closing its send side does not end it.
+func (h *HealthServer) Watch(stream rpc.ServerStream) error {
+	var req HealthCheckRequest
+	if err := stream.Recv(&req); err != nil {
+		return err
+	}
+
+	ch := make(chan Status, 1)
+	h.mu.Lock()
+	h.watchers[req.Service] = append(h.watchers[req.Service], ch)
+	current, ok := h.statuses[req.Service]
+	h.mu.Unlock()
+	defer h.removeWatcher(req.Service, ch)
+
+	if !ok {
+		current = Unknown
+	}
+	if err := stream.Send(&HealthCheckResponse{Status: current}); err != nil {
+		return err
+	}
+
+	for status := range ch {
+		if err := stream.Send(&HealthCheckResponse{Status: status}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *HealthServer) removeWatcher(service string, ch chan Status) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	watchers := h.watchers[service]
+	for i, w := range watchers {
+		if w == ch {
+			h.watchers[service] = append(watchers[:i], watchers[i+1:]...)
+			break
+		}
+	}
+}
+
+// RegisterHealthServer registers hs as the built-in health service on s,
+// mirroring the pattern of a generated RegisterXxxServer function.
+func RegisterHealthServer(s *rpc.Server, hs *HealthServer) {
+	s.RegisterService(&rpc.ServiceDesc{
+		ServiceImpl: hs,
+		ServiceName: ServiceName,
+		Methods: map[string]*rpc.MethodDesc{
+			"Check": {
+				MethodName: "Check",
+				Handler: func(srv any, ctx context.Context, dec func(any) error) (any, context.Context, error) {
+					req := &HealthCheckRequest{}
+					if err := dec(req); err != nil {
+						return nil, ctx, err
+					}
+					resp, err := srv.(*HealthServer).Check(ctx, req)
+					return resp, ctx, err
+				},
+			},
+			"Watch": {
+				MethodName:    "Watch",
+				ServerStreams: true,
+				StreamHandler: func(srv any, stream rpc.ServerStream) error {
+					return srv.(*HealthServer).Watch(stream)
+				},
+			},
+		},
+	}, hs)
+}
@@ -0,0 +1,192 @@
+package health
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/appnet-org/arpc/pkg/registry"
+	"github.com/appnet-org/arpc/pkg/rpc"
+	"github.com/appnet-org/arpc/pkg/serializer"
+)
+
+// outlierThreshold is the number of consecutive failed RPCs to a node
+// (reported via RecordResult) before it's ejected.
+const outlierThreshold = 3
+
+// outlierCooldown is how long an ejected node is excluded from Filter before
+// it's eligible to be re-admitted by a successful probe.
+const outlierCooldown = 30 * time.Second
+
+// nodeState tracks both the active-probe result and the passive failure
+// streak for one node, keyed by its "host:port" address (see nodeAddr).
+type nodeState struct {
+	healthy          bool
+	consecutiveFails int
+	ejectedUntil     time.Time
+}
+
+// nodeAddr is the key Prober tracks state under: the same "host:port" string
+// a Client dials, so RecordResult (fed by a Client's per-address OnResult
+// callback) lines up with the state probe and Filter key off of.
+func nodeAddr(n *registry.Node) string {
+	return fmt.Sprintf("%s:%d", n.Address, n.Port)
+}
+
+// Prober actively polls nodes via the Health/Check RPC and tracks passively
+// observed RPC failures, so a Resolver can filter unhealthy nodes out of the
+// set it hands to balancer.Pick.
+type Prober struct {
+	serializer serializer.Serializer
+	service    string // the service name to ask Check about
+	interval   time.Duration
+
+	mu     sync.Mutex
+	states map[string]*nodeState // keyed by nodeAddr
+
+	// clients caches one rpc.Client per node address. rpc.Client has no
+	// Close, so probe reuses the cached client instead of opening (and
+	// leaking) a new UDP socket every tick.
+	clientsMu sync.Mutex
+	clients   map[string]*rpc.Client
+}
+
+// NewProber creates a Prober that issues Check RPCs for service, every
+// interval, against the nodes returned by the listNodes func given to Start.
+func NewProber(serializer serializer.Serializer, service string, interval time.Duration) *Prober {
+	return &Prober{
+		serializer: serializer,
+		service:    service,
+		interval:   interval,
+		states:     make(map[string]*nodeState),
+		clients:    make(map[string]*rpc.Client),
+	}
+}
+
+// Start launches the background probing loop for nodes, refreshed on each
+// tick by calling listNodes. It runs until ctx is canceled.
+func (p *Prober) Start(ctx context.Context, listNodes func() []*registry.Node) {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for _, n := range listNodes() {
+				p.probe(n)
+			}
+		}
+	}
+}
+
+func (p *Prober) probe(n *registry.Node) {
+	addr := nodeAddr(n)
+	client, err := p.clientFor(addr)
+	if err != nil {
+		p.markUnhealthy(addr)
+		return
+	}
+
+	var resp HealthCheckResponse
+	err = client.Call(context.Background(), ServiceName, "Check", &HealthCheckRequest{Service: p.service}, &resp)
+	if err != nil || resp.Status != Serving {
+		p.markUnhealthy(addr)
+		return
+	}
+	p.markHealthy(addr)
+}
+
+// clientFor returns the cached rpc.Client for addr, creating one on first use.
+func (p *Prober) clientFor(addr string) (*rpc.Client, error) {
+	p.clientsMu.Lock()
+	defer p.clientsMu.Unlock()
+
+	if client, ok := p.clients[addr]; ok {
+		return client, nil
+	}
+	client, err := rpc.NewClient(p.serializer, addr)
+	if err != nil {
+		return nil, err
+	}
+	p.clients[addr] = client
+	return client, nil
+}
+
+// RecordResult records the outcome of an RPC to addr so that ordinary Call
+// failures, not just active probes, count toward ejection. Wire it up via
+// Attach rather than calling it directly.
+func (p *Prober) RecordResult(addr string, err error) {
+	if err == nil {
+		p.markHealthy(addr)
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	st := p.stateLocked(addr)
+	st.consecutiveFails++
+	if st.consecutiveFails >= outlierThreshold {
+		st.healthy = false
+		st.ejectedUntil = time.Now().Add(outlierCooldown)
+	}
+}
+
+// Attach wires client's OnResult callback to RecordResult, so the Client's
+// own Call failures feed this Prober's passive outlier ejection alongside
+// its active probing. client must dial nodes at the same "host:port" this
+// Prober's Filter was given (see nodeAddr).
+func (p *Prober) Attach(client *rpc.Client) {
+	client.OnResult(p.RecordResult)
+}
+
+func (p *Prober) markHealthy(addr string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	st := p.stateLocked(addr)
+	if time.Now().Before(st.ejectedUntil) {
+		return // still within outlierCooldown; stays ejected
+	}
+	st.healthy = true
+	st.consecutiveFails = 0
+	st.ejectedUntil = time.Time{}
+}
+
+func (p *Prober) markUnhealthy(addr string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	st := p.stateLocked(addr)
+	st.healthy = false
+	if st.ejectedUntil.IsZero() {
+		st.ejectedUntil = time.Now().Add(outlierCooldown)
+	}
+}
+
+func (p *Prober) stateLocked(addr string) *nodeState {
+	st, ok := p.states[addr]
+	if !ok {
+		st = &nodeState{healthy: true}
+		p.states[addr] = st
+	}
+	return st
+}
+
+// Filter returns the subset of nodes that are not currently marked unhealthy.
+// Re-admission happens only through markHealthy (a successful probe, or a
+// successful Call reported to RecordResult) after outlierCooldown has passed;
+// elapsing outlierCooldown on its own never re-admits a node.
+func (p *Prober) Filter(nodes []*registry.Node) []*registry.Node {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	filtered := make([]*registry.Node, 0, len(nodes))
+	for _, n := range nodes {
+		st, ok := p.states[nodeAddr(n)]
+		if !ok || st.healthy {
+			filtered = append(filtered, n)
+		}
+	}
+	return filtered
+}
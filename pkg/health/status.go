@@ -0,0 +1,22 @@
+package health
+
+// Status is the serving status of a single service as reported by a
+// HealthServer.
+type Status uint32
+
+const (
+	Unknown Status = iota
+	Serving
+	NotServing
+)
+
+func (s Status) String() string {
+	switch s {
+	case Serving:
+		return "SERVING"
+	case NotServing:
+		return "NOT_SERVING"
+	default:
+		return "UNKNOWN"
+	}
+}
@@ -0,0 +1,106 @@
+// Package consul implements registry.Registry on top of HashiCorp Consul's
+// catalog and health APIs.
+package consul
+
+import (
+	"fmt"
+
+	capi "github.com/hashicorp/consul/api"
+
+	"github.com/appnet-org/arpc/pkg/registry"
+)
+
+// Registry is a registry.Registry backed by a Consul agent.
+type Registry struct {
+	client *capi.Client
+}
+
+// NewRegistry dials the Consul agent at addr (empty uses the local agent
+// default of 127.0.0.1:8500).
+func NewRegistry(addr string) (*Registry, error) {
+	cfg := capi.DefaultConfig()
+	if addr != "" {
+		cfg.Address = addr
+	}
+	client, err := capi.NewClient(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("consul: failed to create client: %w", err)
+	}
+	return &Registry{client: client}, nil
+}
+
+// Register advertises every node of s as a Consul service instance.
+func (r *Registry) Register(s *registry.Service) error {
+	for _, n := range s.Nodes {
+		reg := &capi.AgentServiceRegistration{
+			ID:      n.ID,
+			Name:    s.Name,
+			Address: n.Address,
+			Port:    n.Port,
+			Meta:    n.Metadata,
+			Check: &capi.AgentServiceCheck{
+				TTL:                            "15s",
+				DeregisterCriticalServiceAfter: "1m",
+			},
+		}
+		if err := r.client.Agent().ServiceRegister(reg); err != nil {
+			return fmt.Errorf("consul: failed to register %s/%s: %w", s.Name, n.ID, err)
+		}
+	}
+	return nil
+}
+
+// Deregister removes every node of s from the Consul agent.
+func (r *Registry) Deregister(s *registry.Service) error {
+	for _, n := range s.Nodes {
+		if err := r.client.Agent().ServiceDeregister(n.ID); err != nil {
+			return fmt.Errorf("consul: failed to deregister %s/%s: %w", s.Name, n.ID, err)
+		}
+	}
+	return nil
+}
+
+// GetService returns the currently healthy instances of name.
+func (r *Registry) GetService(name string) (*registry.Service, error) {
+	entries, _, err := r.client.Health().Service(name, "", true, nil)
+	if err != nil {
+		return nil, fmt.Errorf("consul: failed to query service %q: %w", name, err)
+	}
+	return toService(name, entries), nil
+}
+
+// ListServices returns one registry.Service per name known to the catalog.
+func (r *Registry) ListServices() ([]*registry.Service, error) {
+	names, _, err := r.client.Catalog().Services(nil)
+	if err != nil {
+		return nil, fmt.Errorf("consul: failed to list services: %w", err)
+	}
+
+	services := make([]*registry.Service, 0, len(names))
+	for name := range names {
+		svc, err := r.GetService(name)
+		if err != nil {
+			continue
+		}
+		services = append(services, svc)
+	}
+	return services, nil
+}
+
+// Watch long-polls Consul's blocking query API for changes to service.
+func (r *Registry) Watch(service string) (registry.Watcher, error) {
+	return newWatcher(r.client, service), nil
+}
+
+func toService(name string, entries []*capi.ServiceEntry) *registry.Service {
+	nodes := make([]*registry.Node, 0, len(entries))
+	for _, e := range entries {
+		nodes = append(nodes, &registry.Node{
+			ID:       e.Service.ID,
+			Address:  e.Service.Address,
+			Port:     e.Service.Port,
+			Metadata: e.Service.Meta,
+		})
+	}
+	return &registry.Service{Name: name, Nodes: nodes}
+}
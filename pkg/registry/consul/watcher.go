@@ -0,0 +1,47 @@
+package consul
+
+import (
+	"fmt"
+
+	capi "github.com/hashicorp/consul/api"
+
+	"github.com/appnet-org/arpc/pkg/registry"
+)
+
+// watcher implements registry.Watcher using Consul's blocking queries:
+// each Next() call issues a health query with the last-seen WaitIndex and
+// blocks server-side until Consul has something new to report.
+type watcher struct {
+	client    *capi.Client
+	service   string
+	lastIndex uint64
+	stopped   bool
+	stop      chan struct{}
+}
+
+func newWatcher(client *capi.Client, service string) *watcher {
+	return &watcher{client: client, service: service, stop: make(chan struct{})}
+}
+
+func (w *watcher) Next() (*registry.Result, error) {
+	if w.stopped {
+		return nil, fmt.Errorf("consul: watcher stopped")
+	}
+
+	opts := &capi.QueryOptions{WaitIndex: w.lastIndex}
+	entries, meta, err := w.client.Health().Service(w.service, "", true, opts)
+	if err != nil {
+		return nil, fmt.Errorf("consul: watch query failed: %w", err)
+	}
+	w.lastIndex = meta.LastIndex
+
+	return &registry.Result{
+		Action:  registry.Update,
+		Service: toService(w.service, entries),
+	}, nil
+}
+
+func (w *watcher) Stop() {
+	w.stopped = true
+	close(w.stop)
+}
@@ -0,0 +1,80 @@
+package registry
+
+import (
+	"fmt"
+	"net"
+	"time"
+)
+
+// dnsPollInterval is how often a dnsWatcher re-resolves its FQDN.
+const dnsPollInterval = 10 * time.Second
+
+// DNSRegistry resolves services by treating the service name as an FQDN and
+// wrapping the resolved IPs as single-node-per-IP Services. It exists so
+// callers that only ever had a bare hostname keep working against the
+// Registry interface without standing up Consul/etcd/Kubernetes.
+type DNSRegistry struct{}
+
+// NewDNSRegistry creates a DNSRegistry.
+func NewDNSRegistry() *DNSRegistry {
+	return &DNSRegistry{}
+}
+
+// Register is a no-op: DNS-based discovery has no concept of self-registration.
+func (r *DNSRegistry) Register(s *Service) error { return nil }
+
+// Deregister is a no-op for the same reason as Register.
+func (r *DNSRegistry) Deregister(s *Service) error { return nil }
+
+// GetService resolves name via net.LookupIP and returns one Node per IP.
+func (r *DNSRegistry) GetService(name string) (*Service, error) {
+	ips, err := net.LookupIP(name)
+	if err != nil || len(ips) == 0 {
+		return nil, fmt.Errorf("DNS lookup failed for %q: %w", name, err)
+	}
+
+	nodes := make([]*Node, len(ips))
+	for i, ip := range ips {
+		nodes[i] = &Node{ID: ip.String(), Address: ip.String()}
+	}
+	return &Service{Name: name, Nodes: nodes}, nil
+}
+
+// ListServices is unsupported: DNS has no enumeration primitive.
+func (r *DNSRegistry) ListServices() ([]*Service, error) {
+	return nil, fmt.Errorf("registry: ListServices is not supported by DNSRegistry")
+}
+
+// Watch polls DNS every dnsPollInterval and emits an Update event carrying the
+// freshly resolved node set on every poll, whether or not it changed.
+func (r *DNSRegistry) Watch(service string) (Watcher, error) {
+	return newDNSWatcher(r, service), nil
+}
+
+type dnsWatcher struct {
+	registry *DNSRegistry
+	service  string
+	stop     chan struct{}
+}
+
+func newDNSWatcher(r *DNSRegistry, service string) *dnsWatcher {
+	return &dnsWatcher{registry: r, service: service, stop: make(chan struct{})}
+}
+
+func (w *dnsWatcher) Next() (*Result, error) {
+	select {
+	case <-w.stop:
+		return nil, fmt.Errorf("registry: watcher stopped")
+	case <-time.After(dnsPollInterval):
+	}
+
+	svc, err := w.registry.GetService(w.service)
+	if err != nil {
+		return nil, err
+	}
+	return &Result{Action: Update, Service: svc}, nil
+}
+
+func (w *dnsWatcher) Stop() {
+	close(w.stop)
+}
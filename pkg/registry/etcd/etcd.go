@@ -0,0 +1,172 @@
+// Package etcd implements registry.Registry on top of etcd's lease and
+// watch APIs, keying nodes under "/arpc/registry/<service>/<node-id>".
+package etcd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	"github.com/appnet-org/arpc/pkg/registry"
+)
+
+const (
+	keyPrefix   = "/arpc/registry/"
+	leaseTTL    = 15 // seconds
+	dialTimeout = 5 * time.Second
+)
+
+// Registry is a registry.Registry backed by an etcd cluster.
+type Registry struct {
+	client *clientv3.Client
+	leases map[string]clientv3.LeaseID
+}
+
+// NewRegistry dials the etcd cluster at the given endpoints.
+func NewRegistry(endpoints []string) (*Registry, error) {
+	client, err := clientv3.New(clientv3.Config{
+		Endpoints:   endpoints,
+		DialTimeout: dialTimeout,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("etcd: failed to create client: %w", err)
+	}
+	return &Registry{client: client, leases: make(map[string]clientv3.LeaseID)}, nil
+}
+
+func nodeKey(service, nodeID string) string {
+	return keyPrefix + service + "/" + nodeID
+}
+
+// Register writes every node of s under a leased key so it expires
+// automatically if the process dies without deregistering.
+func (r *Registry) Register(s *registry.Service) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
+	defer cancel()
+
+	for _, n := range s.Nodes {
+		lease, err := r.client.Grant(ctx, leaseTTL)
+		if err != nil {
+			return fmt.Errorf("etcd: failed to grant lease: %w", err)
+		}
+
+		data, err := json.Marshal(n)
+		if err != nil {
+			return fmt.Errorf("etcd: failed to marshal node: %w", err)
+		}
+
+		if _, err := r.client.Put(ctx, nodeKey(s.Name, n.ID), string(data), clientv3.WithLease(lease.ID)); err != nil {
+			return fmt.Errorf("etcd: failed to register %s/%s: %w", s.Name, n.ID, err)
+		}
+
+		ch, err := r.client.KeepAlive(context.Background(), lease.ID)
+		if err != nil {
+			return fmt.Errorf("etcd: failed to start keepalive: %w", err)
+		}
+		go drainKeepAlive(ch)
+
+		r.leases[nodeKey(s.Name, n.ID)] = lease.ID
+	}
+	return nil
+}
+
+// drainKeepAlive discards keepalive responses so the channel doesn't block
+// the client's internal refresh loop.
+func drainKeepAlive(ch <-chan *clientv3.LeaseKeepAliveResponse) {
+	for range ch {
+	}
+}
+
+// Deregister revokes the lease backing every node of s, removing its key.
+func (r *Registry) Deregister(s *registry.Service) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
+	defer cancel()
+
+	for _, n := range s.Nodes {
+		key := nodeKey(s.Name, n.ID)
+		if lease, ok := r.leases[key]; ok {
+			if _, err := r.client.Revoke(ctx, lease); err != nil {
+				return fmt.Errorf("etcd: failed to deregister %s/%s: %w", s.Name, n.ID, err)
+			}
+			delete(r.leases, key)
+			continue
+		}
+		if _, err := r.client.Delete(ctx, key); err != nil {
+			return fmt.Errorf("etcd: failed to delete %s/%s: %w", s.Name, n.ID, err)
+		}
+	}
+	return nil
+}
+
+// GetService lists every node key under the service's prefix.
+func (r *Registry) GetService(name string) (*registry.Service, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
+	defer cancel()
+
+	resp, err := r.client.Get(ctx, keyPrefix+name+"/", clientv3.WithPrefix())
+	if err != nil {
+		return nil, fmt.Errorf("etcd: failed to query service %q: %w", name, err)
+	}
+	return toService(name, resp.Kvs)
+}
+
+// ListServices enumerates every key under keyPrefix and groups nodes by the
+// service name embedded in the key path.
+func (r *Registry) ListServices() ([]*registry.Service, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
+	defer cancel()
+
+	resp, err := r.client.Get(ctx, keyPrefix, clientv3.WithPrefix())
+	if err != nil {
+		return nil, fmt.Errorf("etcd: failed to list services: %w", err)
+	}
+
+	byService := map[string][]*clientv3.KeyValue{}
+	for _, kv := range resp.Kvs {
+		name, _, err := splitNodeKey(string(kv.Key))
+		if err != nil {
+			continue
+		}
+		byService[name] = append(byService[name], kv)
+	}
+
+	services := make([]*registry.Service, 0, len(byService))
+	for name, kvs := range byService {
+		svc, err := toService(name, kvs)
+		if err != nil {
+			continue
+		}
+		services = append(services, svc)
+	}
+	return services, nil
+}
+
+// Watch streams etcd watch events for every key under the service's prefix.
+func (r *Registry) Watch(service string) (registry.Watcher, error) {
+	return newWatcher(r.client, service), nil
+}
+
+func toService(name string, kvs []*clientv3.KeyValue) (*registry.Service, error) {
+	nodes := make([]*registry.Node, 0, len(kvs))
+	for _, kv := range kvs {
+		var n registry.Node
+		if err := json.Unmarshal(kv.Value, &n); err != nil {
+			return nil, fmt.Errorf("etcd: failed to unmarshal node: %w", err)
+		}
+		nodes = append(nodes, &n)
+	}
+	return &registry.Service{Name: name, Nodes: nodes}, nil
+}
+
+func splitNodeKey(key string) (service, nodeID string, err error) {
+	rest := key[len(keyPrefix):]
+	for i := len(rest) - 1; i >= 0; i-- {
+		if rest[i] == '/' {
+			return rest[:i], rest[i+1:], nil
+		}
+	}
+	return "", "", fmt.Errorf("etcd: malformed key %q", key)
+}
@@ -0,0 +1,77 @@
+package etcd
+
+import (
+	"context"
+	"fmt"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+
+	"github.com/appnet-org/arpc/pkg/registry"
+)
+
+// watcher implements registry.Watcher over an etcd watch channel scoped to a
+// single service's key prefix. Each event only tells us a key changed, not
+// the service's full node set, so Next() re-queries the prefix (like the
+// Consul watcher's blocking query and the Kubernetes watcher's Endpoints
+// snapshot) rather than reporting just the one node that changed.
+type watcher struct {
+	client  *clientv3.Client
+	service string
+	cancel  context.CancelFunc
+	events  clientv3.WatchChan
+}
+
+func newWatcher(client *clientv3.Client, service string) *watcher {
+	ctx, cancel := context.WithCancel(context.Background())
+	events := client.Watch(ctx, keyPrefix+service+"/", clientv3.WithPrefix())
+	return &watcher{client: client, service: service, cancel: cancel, events: events}
+}
+
+func (w *watcher) Next() (*registry.Result, error) {
+	resp, ok := <-w.events
+	if !ok {
+		return nil, fmt.Errorf("etcd: watch channel closed")
+	}
+	if err := resp.Err(); err != nil {
+		return nil, fmt.Errorf("etcd: watch error: %w", err)
+	}
+
+	for _, ev := range resp.Events {
+		if _, _, err := splitNodeKey(string(ev.Kv.Key)); err != nil {
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), dialTimeout)
+		snapshot, err := w.client.Get(ctx, keyPrefix+w.service+"/", clientv3.WithPrefix())
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("etcd: failed to refresh service %q: %w", w.service, err)
+		}
+		svc, err := toService(w.service, snapshot.Kvs)
+		if err != nil {
+			return nil, fmt.Errorf("etcd: failed to parse service %q: %w", w.service, err)
+		}
+
+		// Action reflects the service as a whole, not the single key that
+		// triggered this event: one node's key expiring out of N is an
+		// Update (the remaining nodes are still live), matching the
+		// Kubernetes watcher's "whole Endpoints object deleted" semantics
+		// for Delete. Deriving Delete from ev.Type alone would make a
+		// routine single-node deregistration wipe the resolver's entire
+		// cached node list for this service.
+		action := registry.Update
+		if len(svc.Nodes) == 0 {
+			action = registry.Delete
+		} else if ev.IsCreate() {
+			action = registry.Create
+		}
+		return &registry.Result{Action: action, Service: svc}, nil
+	}
+
+	// Nothing parsed out of this batch; ask for the next one.
+	return w.Next()
+}
+
+func (w *watcher) Stop() {
+	w.cancel()
+}
@@ -0,0 +1,91 @@
+// Package kubernetes implements registry.Registry on top of the Kubernetes
+// Endpoints API, treating a Service's ready endpoint addresses as nodes.
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/appnet-org/arpc/pkg/registry"
+)
+
+// Registry is a registry.Registry backed by the Kubernetes API server,
+// scoped to a single namespace.
+type Registry struct {
+	clientset *kubernetes.Clientset
+	namespace string
+}
+
+// NewRegistry builds a Registry using in-cluster configuration and watches
+// Endpoints in namespace.
+func NewRegistry(namespace string) (*Registry, error) {
+	cfg, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes: failed to load in-cluster config: %w", err)
+	}
+	clientset, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes: failed to create clientset: %w", err)
+	}
+	return &Registry{clientset: clientset, namespace: namespace}, nil
+}
+
+// Register is a no-op: Kubernetes derives membership from Pod readiness, not
+// explicit registration calls.
+func (r *Registry) Register(s *registry.Service) error { return nil }
+
+// Deregister is a no-op for the same reason as Register.
+func (r *Registry) Deregister(s *registry.Service) error { return nil }
+
+// GetService returns one Node per ready address across all subsets of the
+// Endpoints object for name.
+func (r *Registry) GetService(name string) (*registry.Service, error) {
+	ep, err := r.clientset.CoreV1().Endpoints(r.namespace).Get(context.Background(), name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes: failed to get endpoints %q: %w", name, err)
+	}
+	return toService(name, ep), nil
+}
+
+// ListServices enumerates every Endpoints object in the namespace.
+func (r *Registry) ListServices() ([]*registry.Service, error) {
+	list, err := r.clientset.CoreV1().Endpoints(r.namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes: failed to list endpoints: %w", err)
+	}
+
+	services := make([]*registry.Service, 0, len(list.Items))
+	for i := range list.Items {
+		services = append(services, toService(list.Items[i].Name, &list.Items[i]))
+	}
+	return services, nil
+}
+
+// Watch streams Endpoints changes for service via the Kubernetes watch API.
+func (r *Registry) Watch(service string) (registry.Watcher, error) {
+	return newWatcher(r.clientset, r.namespace, service)
+}
+
+func toService(name string, ep *corev1.Endpoints) *registry.Service {
+	var nodes []*registry.Node
+	for _, subset := range ep.Subsets {
+		for _, addr := range subset.Addresses {
+			for _, port := range subset.Ports {
+				nodes = append(nodes, &registry.Node{
+					ID:      fmt.Sprintf("%s:%d", addr.IP, port.Port),
+					Address: addr.IP,
+					Port:    int(port.Port),
+					Metadata: map[string]string{
+						"portName": port.Name,
+					},
+				})
+			}
+		}
+	}
+	return &registry.Service{Name: name, Nodes: nodes}
+}
@@ -0,0 +1,57 @@
+package kubernetes
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/appnet-org/arpc/pkg/registry"
+)
+
+// watcher implements registry.Watcher over the Kubernetes Endpoints watch API,
+// filtered to a single service name via a field selector.
+type watcher struct {
+	k8sWatcher watch.Interface
+}
+
+func newWatcher(clientset *kubernetes.Clientset, namespace, service string) (*watcher, error) {
+	w, err := clientset.CoreV1().Endpoints(namespace).Watch(context.Background(), metav1.ListOptions{
+		FieldSelector: fmt.Sprintf("metadata.name=%s", service),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("kubernetes: failed to watch endpoints %q: %w", service, err)
+	}
+	return &watcher{k8sWatcher: w}, nil
+}
+
+func (w *watcher) Next() (*registry.Result, error) {
+	event, ok := <-w.k8sWatcher.ResultChan()
+	if !ok {
+		return nil, fmt.Errorf("kubernetes: watch channel closed")
+	}
+
+	ep, ok := event.Object.(*corev1.Endpoints)
+	if !ok {
+		return nil, fmt.Errorf("kubernetes: unexpected watch object type %T", event.Object)
+	}
+
+	var action registry.EventType
+	switch event.Type {
+	case watch.Added:
+		action = registry.Create
+	case watch.Deleted:
+		action = registry.Delete
+	default:
+		action = registry.Update
+	}
+
+	return &registry.Result{Action: action, Service: toService(ep.Name, ep)}, nil
+}
+
+func (w *watcher) Stop() {
+	w.k8sWatcher.Stop()
+}
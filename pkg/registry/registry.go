@@ -0,0 +1,67 @@
+// Package registry defines a backend-agnostic service registry abstraction
+// used to discover and watch the nodes backing an arpc service. Concrete
+// backends live in the consul, etcd, and kubernetes subpackages.
+package registry
+
+import "fmt"
+
+// Node is a single addressable instance of a service.
+type Node struct {
+	ID       string
+	Address  string
+	Port     int
+	Metadata map[string]string
+}
+
+// Service is a named, versioned collection of Nodes.
+type Service struct {
+	Name    string
+	Version string
+	Nodes   []*Node
+}
+
+// EventType describes what changed about a Service in a watch Result.
+type EventType int
+
+const (
+	Create EventType = iota
+	Update
+	Delete
+)
+
+func (e EventType) String() string {
+	switch e {
+	case Create:
+		return "create"
+	case Update:
+		return "update"
+	case Delete:
+		return "delete"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(e))
+	}
+}
+
+// Result is a single change event delivered by a Watcher.
+type Result struct {
+	Action  EventType
+	Service *Service
+}
+
+// Watcher streams change events for the service it was created to watch.
+// Next blocks until an event is available, the watch is stopped, or the
+// underlying connection is lost.
+type Watcher interface {
+	Next() (*Result, error)
+	Stop()
+}
+
+// Registry discovers and tracks the nodes backing services, and lets a
+// process advertise its own presence.
+type Registry interface {
+	Register(s *Service) error
+	Deregister(s *Service) error
+	GetService(name string) (*Service, error)
+	ListServices() ([]*Service, error)
+	Watch(service string) (Watcher, error)
+}
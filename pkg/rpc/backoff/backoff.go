@@ -0,0 +1,64 @@
+// Package backoff implements gRPC-style exponential backoff with jitter for
+// use by rpc.Client's retry policy.
+package backoff
+
+import (
+	"math/rand"
+	"time"
+)
+
+// Config parameterizes the exponential backoff recurrence:
+//
+//	delay = min(BaseDelay * Factor^retries, MaxDelay)
+//
+// then randomized by +/- Jitter as a fraction of delay.
+type Config struct {
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	Factor    float64
+	Jitter    float64
+}
+
+// DefaultConfig mirrors gRPC's default BackoffConfig.
+var DefaultConfig = Config{
+	BaseDelay: 1 * time.Second,
+	MaxDelay:  120 * time.Second,
+	Factor:    1.6,
+	Jitter:    0.2,
+}
+
+// Strategy computes how long to wait before the next attempt once retries
+// attempts have failed (rpc.Client.Call passes 1 before its first retry).
+type Strategy interface {
+	Backoff(retries int) time.Duration
+}
+
+// exponential is the Strategy implementation driven by a Config.
+type exponential struct {
+	cfg Config
+}
+
+// NewExponential returns a Strategy implementing exponential backoff with
+// jitter per cfg.
+func NewExponential(cfg Config) Strategy {
+	return &exponential{cfg: cfg}
+}
+
+// Backoff implements Strategy.
+func (e *exponential) Backoff(retries int) time.Duration {
+	delay := float64(e.cfg.BaseDelay)
+	max := float64(e.cfg.MaxDelay)
+	for i := 0; i < retries && delay < max; i++ {
+		delay *= e.cfg.Factor
+	}
+	if delay > max {
+		delay = max
+	}
+
+	delay *= 1 + e.cfg.Jitter*(2*rand.Float64()-1)
+	if delay < 0 {
+		delay = 0
+	}
+
+	return time.Duration(delay)
+}
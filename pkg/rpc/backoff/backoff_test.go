@@ -0,0 +1,56 @@
+package backoff
+
+import "testing"
+
+func TestBackoffExponentialNoJitter(t *testing.T) {
+	cfg := Config{BaseDelay: 1, MaxDelay: 100, Factor: 2, Jitter: 0}
+	strategy := NewExponential(cfg)
+
+	cases := []struct {
+		retries int
+		want    int64
+	}{
+		{0, 1},
+		{1, 2},
+		{2, 4},
+		{3, 8},
+	}
+	for _, c := range cases {
+		if got := strategy.Backoff(c.retries); int64(got) != c.want {
+			t.Errorf("Backoff(%d) = %d, want %d", c.retries, got, c.want)
+		}
+	}
+}
+
+func TestBackoffClampsToMaxDelay(t *testing.T) {
+	cfg := Config{BaseDelay: 1, MaxDelay: 10, Factor: 2, Jitter: 0}
+	strategy := NewExponential(cfg)
+
+	if got := strategy.Backoff(10); int64(got) != 10 {
+		t.Errorf("Backoff(10) = %d, want clamped to MaxDelay 10", got)
+	}
+}
+
+func TestBackoffJitterStaysInBounds(t *testing.T) {
+	cfg := Config{BaseDelay: 100, MaxDelay: 1000, Factor: 2, Jitter: 0.2}
+	strategy := NewExponential(cfg)
+
+	// retries=0 -> base delay of 100, jittered by +/-20%.
+	for i := 0; i < 100; i++ {
+		got := strategy.Backoff(0)
+		if got < 80 || got > 120 {
+			t.Fatalf("Backoff(0) = %d, want within [80, 120]", got)
+		}
+	}
+}
+
+func TestBackoffNeverNegative(t *testing.T) {
+	cfg := Config{BaseDelay: 1, MaxDelay: 10, Factor: 2, Jitter: 5}
+	strategy := NewExponential(cfg)
+
+	for i := 0; i < 1000; i++ {
+		if got := strategy.Backoff(2); got < 0 {
+			t.Fatalf("Backoff(2) = %d, want >= 0", got)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package rpc
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/appnet-org/arpc/internal/protocol"
+	"github.com/appnet-org/arpc/internal/transport"
+	"github.com/appnet-org/arpc/pkg/metadata"
+	"github.com/appnet-org/arpc/pkg/serializer"
+	"github.com/appnet-org/arpc/pkg/status"
+)
+
+// Client is the core RPC client handling transport, serialization, and request framing.
+type Client struct {
+	transport     *transport.UDPTransport
+	serializer    serializer.Serializer
+	metadataCodec metadata.MetadataCodec
+	addr          string
+
+	// onResult, if set, is called with the address a unary Call attempt
+	// targeted and its outcome (nil on success). health.Prober.Attach wires
+	// this up to drive per-node passive outlier ejection: a run of
+	// consecutive non-nil errors for one address marks that node unhealthy.
+	onResult func(addr string, err error)
+
+	// defaultRetry is the retry policy applied to Call when no per-call
+	// WithRetry CallOption is given. Nil means retries are disabled.
+	defaultRetry *retryPolicy
+
+	// resolve, if set, lets the retry loop re-pick a target address for a
+	// service before each retry attempt instead of reusing addr.
+	resolve func(service string) (string, error)
+
+	// recvOnce starts recvLoop, the single goroutine that reads off the
+	// shared socket and routes frames to pending by rpcID. Every Call and
+	// stream lives behind the same *transport.UDPTransport, so without this
+	// correlation a concurrent Call could read another in-flight call's (or
+	// stream's) response right off the wire.
+	recvOnce  sync.Once
+	pendingMu sync.Mutex
+	pending   map[uint64]chan []byte
+}
+
+// NewClient initializes a new Client instance targeting the given address.
+func NewClient(serializer serializer.Serializer, addr string, opts ...ClientOption) (*Client, error) {
+	udpTransport, err := transport.NewUDPTransport("")
+	if err != nil {
+		return nil, err
+	}
+	c := &Client{
+		transport:     udpTransport,
+		serializer:    serializer,
+		metadataCodec: metadata.MetadataCodec{},
+		addr:          addr,
+		pending:       make(map[uint64]chan []byte),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// register starts recvLoop (once, lazily) and returns the channel it will
+// deliver frames for rpcID on. Callers must unregister(rpcID) once they're
+// done waiting, whether or not a frame ever arrived.
+func (c *Client) register(rpcID uint64) chan []byte {
+	c.recvOnce.Do(func() { go c.recvLoop() })
+
+	ch := make(chan []byte, 16)
+	c.pendingMu.Lock()
+	c.pending[rpcID] = ch
+	c.pendingMu.Unlock()
+	return ch
+}
+
+// unregister removes the bookkeeping for rpcID. Safe to call more than once.
+func (c *Client) unregister(rpcID uint64) {
+	c.pendingMu.Lock()
+	delete(c.pending, rpcID)
+	c.pendingMu.Unlock()
+}
+
+// recvLoop is the sole reader of c.transport's socket. It dispatches each
+// incoming frame to the channel registered for its rpcID, mirroring the
+// server's routeToStream correlation so unrelated in-flight calls and
+// streams on this Client never see each other's responses.
+func (c *Client) recvLoop() {
+	for {
+		data, _, rpcID, err := c.transport.Receive(protocol.MaxUDPPayloadSize)
+		if err != nil {
+			log.Printf("rpc: client receive error: %v", err)
+			continue
+		}
+		if data == nil {
+			continue // still reassembling fragments
+		}
+
+		c.pendingMu.Lock()
+		ch, ok := c.pending[rpcID]
+		c.pendingMu.Unlock()
+		if !ok {
+			continue // no caller waiting: stale, duplicate, or unsolicited frame
+		}
+		ch <- data
+	}
+}
+
+// OnResult registers fn to be called with the address and outcome (error,
+// nil on success) of every subsequent unary Call attempt. Only one callback
+// is kept; calling OnResult again replaces it.
+func (c *Client) OnResult(fn func(addr string, err error)) {
+	c.onResult = fn
+}
+
+// Call invokes a unary RPC method and decodes the response into resp. If a
+// retry policy applies (via WithRetry or the client's default), failed
+// attempts are retried with backoff until one succeeds, the policy's
+// retryable func rejects the error, maxAttempts is exhausted, or ctx is done.
+func (c *Client) Call(ctx context.Context, service, method string, req any, resp any, opts ...CallOption) error {
+	cfg := &callConfig{retry: c.defaultRetry}
+	for _, opt := range opts {
+		opt(cfg)
+	}
+
+	if cfg.retry == nil {
+		return c.callOnce(ctx, service, method, req, resp)
+	}
+
+	policy := cfg.retry
+	var lastErr error
+	for attempt := 0; attempt < policy.maxAttempts; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(policy.strategy.Backoff(attempt)):
+			}
+			if c.resolve != nil {
+				if addr, err := c.resolve(service); err == nil {
+					c.addr = addr
+				}
+			}
+		}
+
+		lastErr = c.callOnce(ctx, service, method, req, resp)
+		if lastErr == nil || !policy.retryable(lastErr) {
+			return lastErr
+		}
+	}
+	return lastErr
+}
+
+// callOnce performs a single unary request/response exchange with no retry.
+func (c *Client) callOnce(ctx context.Context, service, method string, req any, resp any) (err error) {
+	// Captured once so OnResult and the send below always agree on which
+	// node this attempt targeted, even if a concurrent retry attempt on the
+	// same Client mutates c.addr in between.
+	addr := c.addr
+	if c.onResult != nil {
+		defer func() { c.onResult(addr, err) }()
+	}
+
+	reqPayload, err := c.serializer.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	md := metadata.FromOutgoingContext(ctx)
+	headerBytes, err := c.metadataCodec.EncodeHeaders(md)
+	if err != nil {
+		return fmt.Errorf("failed to encode headers: %w", err)
+	}
+
+	framedReq, err := frameResponse(0, service, method, headerBytes, reqPayload, nil)
+	if err != nil {
+		return fmt.Errorf("failed to frame request: %w", err)
+	}
+
+	rpcID := c.transport.NextRPCID()
+	respCh := c.register(rpcID)
+	defer c.unregister(rpcID)
+
+	if err := c.transport.Send(addr, rpcID, framedReq); err != nil {
+		return fmt.Errorf("failed to send request: %w", err)
+	}
+
+	var data []byte
+	select {
+	case data = <-respCh:
+	case <-ctx.Done():
+		return status.Error(status.DeadlineExceeded, ctx.Err().Error())
+	}
+
+	_, _, _, _, respPayload, st, err := parseFramedRequest(data)
+	if err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	if st != nil && st.Code != status.OK {
+		return st
+	}
+
+	return c.serializer.Unmarshal(respPayload, resp)
+}
+
+// NewStream opens a streaming RPC to service/method and returns the client-side
+// Stream used to exchange messages for its duration.
+func (c *Client) NewStream(ctx context.Context, service, method string) (Stream, error) {
+	md := metadata.FromOutgoingContext(ctx)
+	headerBytes, err := c.metadataCodec.EncodeHeaders(md)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode headers: %w", err)
+	}
+
+	rpcID := c.transport.NextRPCID()
+	inbound := c.register(rpcID)
+
+	// Open the stream with a header-only frame so the server can register the
+	// rpcID before any payload-bearing message arrives.
+	framed, err := frameResponse(flagStream|flagHeaderOnly, service, method, headerBytes, nil, nil)
+	if err != nil {
+		c.unregister(rpcID)
+		return nil, fmt.Errorf("failed to frame stream open: %w", err)
+	}
+	if err := c.transport.Send(c.addr, rpcID, framed); err != nil {
+		c.unregister(rpcID)
+		return nil, fmt.Errorf("failed to open stream: %w", err)
+	}
+
+	return newClientStream(ctx, c, rpcID, service, method, inbound), nil
+}
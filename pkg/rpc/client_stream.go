@@ -0,0 +1,75 @@
+package rpc
+
+import (
+	"context"
+	"io"
+
+	"github.com/appnet-org/arpc/pkg/status"
+)
+
+// clientStream is the client-side implementation of Stream returned by
+// Client.NewStream.
+type clientStream struct {
+	ctx     context.Context
+	client  *Client
+	rpcID   uint64
+	service string
+	method  string
+
+	// inbound is the channel Client.recvLoop delivers this stream's frames
+	// to, registered for rpcID via Client.register.
+	inbound chan []byte
+}
+
+func newClientStream(ctx context.Context, c *Client, rpcID uint64, service, method string, inbound chan []byte) *clientStream {
+	return &clientStream{ctx: ctx, client: c, rpcID: rpcID, service: service, method: method, inbound: inbound}
+}
+
+// Send implements Stream.
+func (cs *clientStream) Send(msg any) error {
+	payload, err := cs.client.serializer.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	framed, err := frameResponse(flagStream, cs.service, cs.method, nil, payload, nil)
+	if err != nil {
+		return err
+	}
+	return cs.client.transport.Send(cs.client.addr, cs.rpcID, framed)
+}
+
+// Recv implements Stream. It blocks for the next framed message routed to
+// cs.rpcID by Client.recvLoop and unmarshals its payload into msg.
+func (cs *clientStream) Recv(msg any) error {
+	data := <-cs.inbound
+
+	flags, _, _, _, payload, st, err := parseFramedRequest(data)
+	if err != nil {
+		return err
+	}
+	if st != nil && st.Code != status.OK {
+		cs.client.unregister(cs.rpcID)
+		return st
+	}
+	if flags&flagEndOfStream != 0 {
+		cs.client.unregister(cs.rpcID)
+		return io.EOF
+	}
+
+	return cs.client.serializer.Unmarshal(payload, msg)
+}
+
+// Context implements Stream.
+func (cs *clientStream) Context() context.Context {
+	return cs.ctx
+}
+
+// CloseSend implements Stream, telling the server no more client messages
+// will follow on this rpcID.
+func (cs *clientStream) CloseSend() {
+	framed, err := frameResponse(flagStream|flagEndOfStream, cs.service, cs.method, nil, nil, &status.Status{Code: status.OK})
+	if err != nil {
+		return
+	}
+	_ = cs.client.transport.Send(cs.client.addr, cs.rpcID, framed)
+}
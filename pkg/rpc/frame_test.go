@@ -0,0 +1,83 @@
+package rpc
+
+import (
+	"testing"
+
+	"github.com/appnet-org/arpc/pkg/status"
+)
+
+func TestFrameResponseRoundTrip(t *testing.T) {
+	cases := []struct {
+		name    string
+		flags   byte
+		service string
+		method  string
+		headers []byte
+		payload []byte
+		st      *status.Status
+	}{
+		{"no status", 0, "svc", "Method", []byte("hdr"), []byte("payload"), nil},
+		{"ok status, no headers", 0, "svc", "Method", nil, []byte("payload"), &status.Status{Code: status.OK}},
+		{"error status, no payload", 0, "svc", "Method", nil, nil, &status.Status{Code: status.NotFound, Message: "nope"}},
+		{"stream end-of-stream flags", flagStream | flagEndOfStream, "svc", "Stream", nil, nil, &status.Status{Code: status.OK}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			framed, err := frameResponse(c.flags, c.service, c.method, c.headers, c.payload, c.st)
+			if err != nil {
+				t.Fatalf("frameResponse: %v", err)
+			}
+
+			flags, service, method, headers, payload, st, err := parseFramedRequest(framed)
+			if err != nil {
+				t.Fatalf("parseFramedRequest: %v", err)
+			}
+
+			wantFlags := c.flags
+			if c.st != nil {
+				wantFlags |= flagHasStatus
+			}
+			if flags != wantFlags {
+				t.Errorf("flags = %08b, want %08b", flags, wantFlags)
+			}
+			if service != c.service || method != c.method {
+				t.Errorf("service/method = %q/%q, want %q/%q", service, method, c.service, c.method)
+			}
+			if string(headers) != string(c.headers) {
+				t.Errorf("headers = %q, want %q", headers, c.headers)
+			}
+			if string(payload) != string(c.payload) {
+				t.Errorf("payload = %q, want %q", payload, c.payload)
+			}
+
+			if c.st == nil {
+				if st != nil {
+					t.Errorf("status = %+v, want nil", st)
+				}
+				return
+			}
+			if st == nil || st.Code != c.st.Code || st.Message != c.st.Message {
+				t.Errorf("status = %+v, want %+v", st, c.st)
+			}
+		})
+	}
+}
+
+func TestFrameResponseClearsHasStatusFlagWhenNil(t *testing.T) {
+	framed, err := frameResponse(flagHasStatus, "svc", "Method", nil, nil, nil)
+	if err != nil {
+		t.Fatalf("frameResponse: %v", err)
+	}
+
+	flags, _, _, _, _, st, err := parseFramedRequest(framed)
+	if err != nil {
+		t.Fatalf("parseFramedRequest: %v", err)
+	}
+	if flags&flagHasStatus != 0 {
+		t.Errorf("flagHasStatus set despite nil status")
+	}
+	if st != nil {
+		t.Errorf("status = %+v, want nil", st)
+	}
+}
@@ -0,0 +1,80 @@
+package rpc
+
+import (
+	"errors"
+
+	"github.com/appnet-org/arpc/pkg/rpc/backoff"
+	"github.com/appnet-org/arpc/pkg/status"
+)
+
+// retryPolicy bundles the knobs a retrying Call needs: how many attempts to
+// make in total, which errors are worth retrying, and how long to wait
+// between attempts.
+type retryPolicy struct {
+	maxAttempts int
+	retryable   func(error) bool
+	strategy    backoff.Strategy
+}
+
+// callConfig accumulates the CallOptions passed to a single Call.
+type callConfig struct {
+	retry *retryPolicy
+}
+
+// CallOption customizes the behavior of a single Client.Call.
+type CallOption func(*callConfig)
+
+// ClientOption customizes a Client at construction time.
+type ClientOption func(*Client)
+
+// WithRetry returns a CallOption that retries a unary Call up to maxAttempts
+// times (including the first), retrying only errors retryable reports true
+// for, and waiting strategy.Backoff(attempt) between attempts. It has no
+// effect on streaming RPCs, which are never retried.
+func WithRetry(maxAttempts int, retryable func(error) bool, strategy backoff.Strategy) CallOption {
+	return func(cfg *callConfig) {
+		cfg.retry = &retryPolicy{maxAttempts: maxAttempts, retryable: retryable, strategy: strategy}
+	}
+}
+
+// WithDefaultRetry sets the retry policy every Call uses unless overridden by
+// a per-call WithRetry.
+func WithDefaultRetry(maxAttempts int, retryable func(error) bool, strategy backoff.Strategy) ClientOption {
+	return func(c *Client) {
+		c.defaultRetry = &retryPolicy{maxAttempts: maxAttempts, retryable: retryable, strategy: strategy}
+	}
+}
+
+// WithResolver lets the retry loop re-pick a target address for the called
+// service before each retry attempt, instead of reusing the address the
+// Client was constructed with.
+func WithResolver(resolve func(service string) (string, error)) ClientOption {
+	return func(c *Client) {
+		c.resolve = resolve
+	}
+}
+
+// retryableCodes are the status.Codes IsRetryableTransport treats as worth
+// another attempt: conditions that plausibly clear up on a different node or
+// a later try. Codes like InvalidArgument are deliberately excluded since
+// retrying them just repeats the same failure.
+var retryableCodes = map[status.Code]bool{
+	status.Unavailable:       true,
+	status.DeadlineExceeded:  true,
+	status.ResourceExhausted: true,
+}
+
+// IsRetryableTransport is a default retryable func: it retries errors that
+// carry one of retryableCodes, plus bare transport timeouts that never made
+// it as far as a *status.Status.
+func IsRetryableTransport(err error) bool {
+	if err == nil {
+		return false
+	}
+	if retryableCodes[status.CodeOf(err)] {
+		return true
+	}
+
+	var timeoutErr interface{ Timeout() bool }
+	return errors.As(err, &timeoutErr) && timeoutErr.Timeout()
+}
@@ -5,23 +5,65 @@ import (
 	"context"
 	"encoding/binary"
 	"log"
+	"sync"
 
 	"github.com/appnet-org/arpc/internal/protocol"
 	"github.com/appnet-org/arpc/internal/transport"
 	"github.com/appnet-org/arpc/pkg/metadata"
 	"github.com/appnet-org/arpc/pkg/rpc/element"
 	"github.com/appnet-org/arpc/pkg/serializer"
+	"github.com/appnet-org/arpc/pkg/status"
 )
 
-// MethodHandler defines the function signature for handling an RPC method.
+// MethodHandler defines the function signature for handling a unary RPC method.
 type MethodHandler func(srv any, ctx context.Context, dec func(any) error) (resp any, newCtx context.Context, err error)
 
-// MethodDesc represents an RPC service's method specification.
+// StreamHandler defines the function signature for handling a streaming RPC method.
+// Unlike MethodHandler, it does not return a response directly; instead it drives
+// the exchange itself through the supplied Stream.
+type StreamHandler func(srv any, stream ServerStream) error
+
+// Stream represents one in-flight RPC's message exchange, independent of direction.
+type Stream interface {
+	// Send marshals and sends msg as the next message on the stream.
+	Send(msg any) error
+	// Recv blocks until the next message is available and unmarshals it into msg.
+	Recv(msg any) error
+	// Context returns the context associated with the stream.
+	Context() context.Context
+	// CloseSend signals that no more messages will be sent on this stream.
+	CloseSend()
+}
+
+// ServerStream is the server-side view of a Stream.
+type ServerStream interface {
+	Stream
+}
+
+// MethodDesc represents an RPC service's method specification. A method is either
+// unary (Handler set) or streaming (StreamHandler set), never both.
 type MethodDesc struct {
 	MethodName string
 	Handler    MethodHandler
+
+	// ServerStreams indicates the server may send multiple responses.
+	ServerStreams bool
+	// ClientStreams indicates the client may send multiple requests.
+	ClientStreams bool
+	// StreamHandler, when set, marks this method as streaming.
+	StreamHandler StreamHandler
 }
 
+// frame flag bits carried in the first byte of every framed request/response.
+const (
+	flagStream      byte = 1 << 0 // message belongs to a multi-message stream
+	flagEndOfStream byte = 1 << 1 // final message on the stream
+	flagHeaderOnly  byte = 1 << 2 // frame carries only headers, no payload
+	flagHasStatus   byte = 1 << 3 // frame carries a status.Status trailer
+)
+
+var statusCodec status.Codec
+
 // ServiceDesc describes an RPC service, including its implementation and methods.
 type ServiceDesc struct {
 	ServiceImpl any
@@ -36,6 +78,9 @@ type Server struct {
 	metadataCodec   metadata.MetadataCodec
 	services        map[string]*ServiceDesc
 	rpcElementChain *element.RPCElementChain
+
+	streamsMu sync.Mutex
+	streams   map[uint64]*serverStream // in-flight streams keyed by rpcID
 }
 
 // NewServer initializes a new Server instance with the given address and serializer.
@@ -50,6 +95,7 @@ func NewServer(addr string, serializer serializer.Serializer, rpcElements []elem
 		metadataCodec:   metadata.MetadataCodec{},
 		services:        make(map[string]*ServiceDesc),
 		rpcElementChain: element.NewRPCElementChain(rpcElements...),
+		streams:         make(map[uint64]*serverStream),
 	}, nil
 }
 
@@ -59,37 +105,67 @@ func (s *Server) RegisterService(desc *ServiceDesc, impl any) {
 	log.Printf("Registered service: %s\n", desc.ServiceName)
 }
 
-// parseFramedRequest extracts service, method, header, and payload segments from a request frame.
-func parseFramedRequest(data []byte) (string, string, []byte, []byte, error) {
+// parseFramedRequest extracts the flag byte, service, method, header, and payload
+// segments from a request frame, plus a trailing status.Status when flagHasStatus
+// is set (st is nil otherwise).
+func parseFramedRequest(data []byte) (flags byte, service, method string, headers, payload []byte, st *status.Status, err error) {
 	offset := 0
 
+	// Flags
+	flags = data[offset]
+	offset += 1
+
 	// Service
 	serviceLen := int(binary.LittleEndian.Uint16(data[offset:]))
 	offset += 2
-	service := string(data[offset : offset+serviceLen])
+	service = string(data[offset : offset+serviceLen])
 	offset += serviceLen
 
 	// Method
 	methodLen := int(binary.LittleEndian.Uint16(data[offset:]))
 	offset += 2
-	method := string(data[offset : offset+methodLen])
+	method = string(data[offset : offset+methodLen])
 	offset += methodLen
 
 	// Headers
 	headerLen := int(binary.LittleEndian.Uint16(data[offset:]))
 	offset += 2
-	headers := data[offset : offset+headerLen]
+	headers = data[offset : offset+headerLen]
 	offset += headerLen
 
 	// Payload
-	payload := data[offset:]
+	payloadLen := int(binary.LittleEndian.Uint32(data[offset:]))
+	offset += 4
+	payload = data[offset : offset+payloadLen]
+	offset += payloadLen
+
+	// Status trailer
+	if flags&flagHasStatus != 0 {
+		st, err = statusCodec.DecodeTrailer(data[offset:])
+		if err != nil {
+			return flags, service, method, headers, payload, nil, err
+		}
+	}
 
-	return service, method, headers, payload, nil
+	return flags, service, method, headers, payload, st, nil
 }
 
-func frameResponse(service, method string, headers []byte, payload []byte) ([]byte, error) {
+// frameResponse assembles a frame. st may be nil, in which case no status
+// trailer is written and flagHasStatus is cleared from flags.
+func frameResponse(flags byte, service, method string, headers []byte, payload []byte, st *status.Status) ([]byte, error) {
 	buf := new(bytes.Buffer)
 
+	if st != nil {
+		flags |= flagHasStatus
+	} else {
+		flags &^= flagHasStatus
+	}
+
+	// Write flags
+	if err := buf.WriteByte(flags); err != nil {
+		return nil, err
+	}
+
 	// Write service name
 	if err := binary.Write(buf, binary.LittleEndian, uint16(len(service))); err != nil {
 		return nil, err
@@ -115,10 +191,24 @@ func frameResponse(service, method string, headers []byte, payload []byte) ([]by
 	}
 
 	// Write payload
+	if err := binary.Write(buf, binary.LittleEndian, uint32(len(payload))); err != nil {
+		return nil, err
+	}
 	if _, err := buf.Write(payload); err != nil {
 		return nil, err
 	}
 
+	// Write status trailer
+	if st != nil {
+		trailer, err := statusCodec.EncodeTrailer(st)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := buf.Write(trailer); err != nil {
+			return nil, err
+		}
+	}
+
 	return buf.Bytes(), nil
 }
 
@@ -139,12 +229,18 @@ func (s *Server) Start() {
 		}
 
 		// Parse request header and payload
-		serviceName, methodName, reqHeaderBytes, reqPayloadBytes, err := parseFramedRequest(data)
+		flags, serviceName, methodName, reqHeaderBytes, reqPayloadBytes, _, err := parseFramedRequest(data)
 		if err != nil {
 			log.Printf("Failed to parse framed request: %v", err)
 			continue
 		}
 
+		// If this rpcID already belongs to an established stream, route the fragment
+		// to the waiting Recv instead of dispatching it through s.services again.
+		if s.routeToStream(rpcID, flags, reqPayloadBytes) {
+			continue
+		}
+
 		// Create RPC request for element processing
 		rpcReq := &element.RPCRequest{
 			ID:          rpcID,
@@ -157,25 +253,29 @@ func (s *Server) Start() {
 		rpcReq, err = s.rpcElementChain.ProcessRequest(context.Background(), rpcReq)
 		if err != nil {
 			log.Printf("RPC element processing error: %v", err)
+			s.replyError(addr.String(), rpcID, serviceName, methodName, status.Error(status.Internal, err.Error()))
 			continue
 		}
 
 		// Lookup service and method
 		svcDesc, ok := s.services[rpcReq.ServiceName]
 		if !ok {
-			log.Printf("Unknown service: %s", rpcReq.ServiceName)
+			s.replyError(addr.String(), rpcID, serviceName, methodName,
+				status.Errorf(status.Unimplemented, "unknown service: %s", rpcReq.ServiceName))
 			continue
 		}
 		methodDesc, ok := svcDesc.Methods[rpcReq.Method]
 		if !ok {
-			log.Printf("Unknown method: %s.%s", rpcReq.ServiceName, rpcReq.Method)
+			s.replyError(addr.String(), rpcID, serviceName, methodName,
+				status.Errorf(status.Unimplemented, "unknown method: %s.%s", rpcReq.ServiceName, rpcReq.Method))
 			continue
 		}
 
 		// Decode headers
 		md, err := s.metadataCodec.DecodeHeaders(reqHeaderBytes)
 		if err != nil {
-			log.Printf("Failed to decode headers: %v", err)
+			s.replyError(addr.String(), rpcID, serviceName, methodName,
+				status.Errorf(status.Internal, "failed to decode headers: %v", err))
 			continue
 		}
 		ctx := metadata.NewIncomingContext(context.Background(), md)
@@ -186,12 +286,20 @@ func (s *Server) Start() {
 			log.Printf("  %s: %s", k, v)
 		}
 
+		// Streaming methods don't fit the single request/response shape below: hand
+		// off to a dedicated goroutine that owns the stream for its lifetime.
+		if methodDesc.StreamHandler != nil {
+			s.startStream(rpcID, addr.String(), flags, ctx, svcDesc, methodDesc, reqPayloadBytes)
+			continue
+		}
+
 		// Invoke method handler
 		resp, ctx, err := methodDesc.Handler(svcDesc.ServiceImpl, ctx, func(v any) error {
 			return s.serializer.Unmarshal(rpcReq.Payload.([]byte), v)
 		})
 		if err != nil {
-			log.Printf("Handler error: %v", err)
+			st, _ := status.FromError(err)
+			s.replyError(addr.String(), rpcID, rpcReq.ServiceName, rpcReq.Method, st)
 			continue
 		}
 
@@ -204,14 +312,14 @@ func (s *Server) Start() {
 		// Process response through RPC elements
 		rpcResp, err = s.rpcElementChain.ProcessResponse(ctx, rpcResp)
 		if err != nil {
-			log.Printf("RPC element response processing error: %v", err)
+			s.replyError(addr.String(), rpcID, rpcReq.ServiceName, rpcReq.Method, status.Error(status.Internal, err.Error()))
 			continue
 		}
 
 		// Serialize response
 		respPayloadBytes, err := s.serializer.Marshal(rpcResp.Result)
 		if err != nil {
-			log.Printf("Error marshaling response: %v", err)
+			s.replyError(addr.String(), rpcID, rpcReq.ServiceName, rpcReq.Method, status.Error(status.Internal, err.Error()))
 			continue
 		}
 
@@ -219,12 +327,12 @@ func (s *Server) Start() {
 		respMD := metadata.FromOutgoingContext(ctx)
 		respHeaderBytes, err := s.metadataCodec.EncodeHeaders(respMD)
 		if err != nil {
-			log.Printf("Failed to encode response headers: %v", err)
+			s.replyError(addr.String(), rpcID, rpcReq.ServiceName, rpcReq.Method, status.Error(status.Internal, err.Error()))
 			continue
 		}
 
 		// Frame response
-		framedResp, err := frameResponse(rpcReq.ServiceName, rpcReq.Method, respHeaderBytes, respPayloadBytes)
+		framedResp, err := frameResponse(0, rpcReq.ServiceName, rpcReq.Method, respHeaderBytes, respPayloadBytes, &status.Status{Code: status.OK})
 		if err != nil {
 			log.Printf("Failed to frame response: %v", err)
 			continue
@@ -236,3 +344,19 @@ func (s *Server) Start() {
 		}
 	}
 }
+
+// replyError sends st back to the caller as a status-only response frame
+// (no payload), so failures that used to be dropped with only a log line are
+// now visible to the client as a typed *status.Status error.
+func (s *Server) replyError(addr string, rpcID uint64, service, method string, st *status.Status) {
+	log.Printf("%s.%s error: %s: %s", service, method, st.Code, st.Message)
+
+	framed, err := frameResponse(0, service, method, nil, nil, st)
+	if err != nil {
+		log.Printf("Failed to frame status response: %v", err)
+		return
+	}
+	if err := s.transport.Send(addr, rpcID, framed); err != nil {
+		log.Printf("Failed to send status response: %v", err)
+	}
+}
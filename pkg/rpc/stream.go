@@ -0,0 +1,157 @@
+package rpc
+
+import (
+	"context"
+	"io"
+	"log"
+
+	"github.com/appnet-org/arpc/pkg/serializer"
+	"github.com/appnet-org/arpc/pkg/status"
+)
+
+// serverStream is the server-side implementation of ServerStream. One is created
+// per streaming rpcID and lives until the client sends flagEndOfStream or the
+// StreamHandler returns.
+type serverStream struct {
+	ctx        context.Context
+	server     *Server
+	serializer serializer.Serializer
+	addr       string
+	rpcID      uint64
+	service    string
+	method     string
+
+	inbound chan []byte // fragments routed in from Server.Start
+	closed  bool
+}
+
+func newServerStream(ctx context.Context, s *Server, addr string, rpcID uint64, service, method string) *serverStream {
+	return &serverStream{
+		ctx:        ctx,
+		server:     s,
+		serializer: s.serializer,
+		addr:       addr,
+		rpcID:      rpcID,
+		service:    service,
+		method:     method,
+		inbound:    make(chan []byte, 16),
+	}
+}
+
+// Send implements Stream.
+func (ss *serverStream) Send(msg any) error {
+	payload, err := ss.serializer.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	framed, err := frameResponse(flagStream, ss.service, ss.method, nil, payload, nil)
+	if err != nil {
+		return err
+	}
+	return ss.server.transport.Send(ss.addr, ss.rpcID, framed)
+}
+
+// Recv implements Stream. It blocks until the client sends the next message or
+// closes its send side.
+func (ss *serverStream) Recv(msg any) error {
+	payload, ok := <-ss.inbound
+	if !ok {
+		return io.EOF
+	}
+	return ss.serializer.Unmarshal(payload, msg)
+}
+
+// Context implements Stream.
+func (ss *serverStream) Context() context.Context {
+	return ss.ctx
+}
+
+// CloseSend implements Stream. It tells the client no more server messages
+// will follow. It only half-closes the server's send side: the client may
+// still be sending, so the stream's bookkeeping stays in place until the
+// client's flagEndOfStream arrives (routeToStream) or the StreamHandler
+// returns (startStream) — untracking here would let a later client frame
+// fall through to Server.Start's normal dispatch and spawn a second handler
+// for this rpcID.
+func (ss *serverStream) CloseSend() {
+	framed, err := frameResponse(flagStream|flagEndOfStream, ss.service, ss.method, nil, nil, &status.Status{Code: status.OK})
+	if err == nil {
+		if err := ss.server.transport.Send(ss.addr, ss.rpcID, framed); err != nil {
+			log.Printf("Failed to send end-of-stream frame: %v", err)
+		}
+	}
+}
+
+// routeToStream delivers an inbound fragment to an already-established stream's
+// Recv loop. It reports whether rpcID matched a known stream so the caller can
+// skip re-dispatching the fragment through s.services.
+func (s *Server) routeToStream(rpcID uint64, flags byte, payload []byte) bool {
+	s.streamsMu.Lock()
+	ss, ok := s.streams[rpcID]
+	s.streamsMu.Unlock()
+	if !ok {
+		return false
+	}
+
+	if flags&flagEndOfStream != 0 {
+		close(ss.inbound)
+		s.untrackStream(rpcID)
+		return true
+	}
+
+	ss.inbound <- payload
+	return true
+}
+
+// startStream registers a new stream for rpcID and runs the method's
+// StreamHandler on its own goroutine, feeding it the first fragment already
+// received on the wire.
+func (s *Server) startStream(rpcID uint64, addr string, flags byte, ctx context.Context, svcDesc *ServiceDesc, methodDesc *MethodDesc, firstPayload []byte) {
+	ss := newServerStream(ctx, s, addr, rpcID, svcDesc.ServiceName, methodDesc.MethodName)
+
+	s.streamsMu.Lock()
+	s.streams[rpcID] = ss
+	s.streamsMu.Unlock()
+
+	if len(firstPayload) > 0 {
+		ss.inbound <- firstPayload
+	}
+	if flags&flagEndOfStream != 0 {
+		close(ss.inbound)
+		s.untrackStream(rpcID)
+	}
+
+	go func() {
+		if err := methodDesc.StreamHandler(svcDesc.ServiceImpl, ss); err != nil {
+			log.Printf("Stream handler error for %s.%s: %v", svcDesc.ServiceName, methodDesc.MethodName, err)
+			st, _ := status.FromError(err)
+			s.replyStreamError(addr, rpcID, svcDesc.ServiceName, methodDesc.MethodName, st)
+		}
+		s.untrackStream(rpcID)
+	}()
+}
+
+// replyStreamError sends a terminal status/end-of-stream frame for rpcID, the
+// stream counterpart to Server.replyError. Without this, a StreamHandler that
+// returns an error leaves the client's Recv blocked forever instead of
+// surfacing the failure.
+func (s *Server) replyStreamError(addr string, rpcID uint64, service, method string, st *status.Status) {
+	log.Printf("%s.%s stream error: %s: %s", service, method, st.Code, st.Message)
+
+	framed, err := frameResponse(flagStream|flagEndOfStream, service, method, nil, nil, st)
+	if err != nil {
+		log.Printf("Failed to frame stream status response: %v", err)
+		return
+	}
+	if err := s.transport.Send(addr, rpcID, framed); err != nil {
+		log.Printf("Failed to send stream status response: %v", err)
+	}
+}
+
+// untrackStream removes the bookkeeping for a finished stream. Safe to call
+// more than once for the same rpcID.
+func (s *Server) untrackStream(rpcID uint64) {
+	s.streamsMu.Lock()
+	delete(s.streams, rpcID)
+	s.streamsMu.Unlock()
+}
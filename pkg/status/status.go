@@ -0,0 +1,120 @@
+// Package status defines the structured error model used across arpc's
+// client and server: a Code drawn from a fixed, gRPC-compatible set plus a
+// human-readable message and opaque details, carried as a trailer on every
+// unary response frame and on a stream's final frame instead of only being
+// logged.
+package status
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Code classifies the outcome of an RPC.
+type Code uint32
+
+const (
+	OK Code = iota
+	Canceled
+	Unknown
+	InvalidArgument
+	DeadlineExceeded
+	NotFound
+	AlreadyExists
+	PermissionDenied
+	ResourceExhausted
+	FailedPrecondition
+	Aborted
+	OutOfRange
+	Unimplemented
+	Internal
+	Unavailable
+	DataLoss
+	Unauthenticated
+)
+
+func (c Code) String() string {
+	switch c {
+	case OK:
+		return "OK"
+	case Canceled:
+		return "Canceled"
+	case Unknown:
+		return "Unknown"
+	case InvalidArgument:
+		return "InvalidArgument"
+	case DeadlineExceeded:
+		return "DeadlineExceeded"
+	case NotFound:
+		return "NotFound"
+	case AlreadyExists:
+		return "AlreadyExists"
+	case PermissionDenied:
+		return "PermissionDenied"
+	case ResourceExhausted:
+		return "ResourceExhausted"
+	case FailedPrecondition:
+		return "FailedPrecondition"
+	case Aborted:
+		return "Aborted"
+	case OutOfRange:
+		return "OutOfRange"
+	case Unimplemented:
+		return "Unimplemented"
+	case Internal:
+		return "Internal"
+	case Unavailable:
+		return "Unavailable"
+	case DataLoss:
+		return "DataLoss"
+	case Unauthenticated:
+		return "Unauthenticated"
+	default:
+		return fmt.Sprintf("Code(%d)", uint32(c))
+	}
+}
+
+// Status is a structured RPC error: a Code, a human-readable Message, and
+// zero or more opaque Details (e.g. serialized protobuf/capnp messages)
+// carried alongside it.
+type Status struct {
+	Code    Code
+	Message string
+	Details [][]byte
+}
+
+// Error implements the error interface.
+func (s *Status) Error() string {
+	return fmt.Sprintf("rpc error: code = %s desc = %s", s.Code, s.Message)
+}
+
+// Error constructs a *Status with the given code and message as an error.
+func Error(code Code, msg string) error {
+	return &Status{Code: code, Message: msg}
+}
+
+// Errorf is Error with fmt.Sprintf-style formatting of the message.
+func Errorf(code Code, format string, a ...any) error {
+	return Error(code, fmt.Sprintf(format, a...))
+}
+
+// FromError unwraps err into a *Status. If err is nil, it returns a Status
+// with Code OK. If err does not wrap a *Status, it returns one with Code
+// Unknown and ok=false.
+func FromError(err error) (s *Status, ok bool) {
+	if err == nil {
+		return &Status{Code: OK}, true
+	}
+	var st *Status
+	if errors.As(err, &st) {
+		return st, true
+	}
+	return &Status{Code: Unknown, Message: err.Error()}, false
+}
+
+// CodeOf returns the Code carried by err (OK for nil, Unknown for an error
+// that isn't a *Status), for callers that only care about the code.
+func CodeOf(err error) Code {
+	s, _ := FromError(err)
+	return s.Code
+}
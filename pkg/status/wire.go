@@ -0,0 +1,71 @@
+package status
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+)
+
+// Codec encodes and decodes a Status as the trailer appended to a framed
+// response: code (uint32) + message length + message + details count +
+// each detail's length-prefixed bytes.
+type Codec struct{}
+
+// EncodeTrailer serializes s.
+func (Codec) EncodeTrailer(s *Status) ([]byte, error) {
+	buf := new(bytes.Buffer)
+
+	if err := binary.Write(buf, binary.LittleEndian, uint32(s.Code)); err != nil {
+		return nil, err
+	}
+
+	if err := binary.Write(buf, binary.LittleEndian, uint16(len(s.Message))); err != nil {
+		return nil, err
+	}
+	if _, err := buf.WriteString(s.Message); err != nil {
+		return nil, err
+	}
+
+	if err := binary.Write(buf, binary.LittleEndian, uint16(len(s.Details))); err != nil {
+		return nil, err
+	}
+	for _, d := range s.Details {
+		if err := binary.Write(buf, binary.LittleEndian, uint32(len(d))); err != nil {
+			return nil, err
+		}
+		if _, err := buf.Write(d); err != nil {
+			return nil, err
+		}
+	}
+
+	return buf.Bytes(), nil
+}
+
+// DecodeTrailer parses a Status previously produced by EncodeTrailer.
+func (Codec) DecodeTrailer(data []byte) (*Status, error) {
+	offset := 0
+	if len(data) < 4 {
+		return nil, fmt.Errorf("status: trailer too short")
+	}
+
+	code := Code(binary.LittleEndian.Uint32(data[offset:]))
+	offset += 4
+
+	msgLen := int(binary.LittleEndian.Uint16(data[offset:]))
+	offset += 2
+	message := string(data[offset : offset+msgLen])
+	offset += msgLen
+
+	detailsCount := int(binary.LittleEndian.Uint16(data[offset:]))
+	offset += 2
+
+	details := make([][]byte, 0, detailsCount)
+	for i := 0; i < detailsCount; i++ {
+		dLen := int(binary.LittleEndian.Uint32(data[offset:]))
+		offset += 4
+		details = append(details, data[offset:offset+dLen])
+		offset += dLen
+	}
+
+	return &Status{Code: code, Message: message, Details: details}, nil
+}
@@ -0,0 +1,68 @@
+package status
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCodecRoundTrip(t *testing.T) {
+	cases := []*Status{
+		{Code: OK},
+		{Code: NotFound, Message: "node not found"},
+		{Code: Internal, Message: "boom", Details: [][]byte{[]byte("a"), []byte("bb")}},
+		{Code: Unavailable, Message: ""},
+	}
+
+	var codec Codec
+	for _, want := range cases {
+		trailer, err := codec.EncodeTrailer(want)
+		if err != nil {
+			t.Fatalf("EncodeTrailer(%+v): %v", want, err)
+		}
+		got, err := codec.DecodeTrailer(trailer)
+		if err != nil {
+			t.Fatalf("DecodeTrailer: %v", err)
+		}
+		if got.Code != want.Code || got.Message != want.Message || len(got.Details) != len(want.Details) {
+			t.Errorf("round trip mismatch: got %+v, want %+v", got, want)
+			continue
+		}
+		for i := range want.Details {
+			if !reflect.DeepEqual(got.Details[i], want.Details[i]) {
+				t.Errorf("detail %d mismatch: got %q, want %q", i, got.Details[i], want.Details[i])
+			}
+		}
+	}
+}
+
+func TestDecodeTrailerTooShort(t *testing.T) {
+	var codec Codec
+	if _, err := codec.DecodeTrailer([]byte{1, 2}); err == nil {
+		t.Fatal("expected error for truncated trailer, got nil")
+	}
+}
+
+func TestFromError(t *testing.T) {
+	if st, ok := FromError(nil); !ok || st.Code != OK {
+		t.Errorf("FromError(nil) = %+v, %v; want Code OK, true", st, ok)
+	}
+
+	wrapped := Error(NotFound, "missing")
+	if st, ok := FromError(wrapped); !ok || st.Code != NotFound {
+		t.Errorf("FromError(status error) = %+v, %v; want Code NotFound, true", st, ok)
+	}
+
+	if st, ok := FromError(errors.New("plain")); ok || st.Code != Unknown {
+		t.Errorf("FromError(plain error) = %+v, %v; want Code Unknown, false", st, ok)
+	}
+}
+
+func TestCodeOf(t *testing.T) {
+	if c := CodeOf(nil); c != OK {
+		t.Errorf("CodeOf(nil) = %v, want OK", c)
+	}
+	if c := CodeOf(Errorf(DeadlineExceeded, "timed out after %s", "1s")); c != DeadlineExceeded {
+		t.Errorf("CodeOf(...) = %v, want DeadlineExceeded", c)
+	}
+}